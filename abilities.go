@@ -0,0 +1,8 @@
+package koffing
+
+import "github.com/txfs19260817/koffing-go/dex"
+
+// AbilityData returns the data of the ability of the receiver, or false if it has none or an unknown one.
+func (p Pokemon) AbilityData() (dex.AbilityData, bool) {
+	return dex.Ability(p.Ability)
+}
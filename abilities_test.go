@@ -0,0 +1,31 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePokemon_AbilityData() {
+	var p Pokemon
+	_ = p.FromShowdown(koffingSet)
+	ability, _ := p.AbilityData()
+	fmt.Println(ability.Name, ability.Breakable)
+	fmt.Println(ability.ShortDesc)
+	// Output:
+	// Levitate true
+	// This Pokemon is immune to Ground; Gravity/Ingrain/Smack Down/Iron Ball nullify it.
+}
+
+func TestPokemon_AbilityData(t *testing.T) {
+	t.Parallel()
+	ability, ok := Pokemon{Ability: "sand stream"}.AbilityData()
+	assert.True(t, ok)
+	assert.Equal(t, "Sand Stream", ability.Name)
+	assert.True(t, ability.SetsWeather())
+	for _, name := range []string{"", "a"} {
+		_, ok := Pokemon{Ability: name}.AbilityData()
+		assert.False(t, ok, name)
+	}
+}
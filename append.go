@@ -0,0 +1,205 @@
+package koffing
+
+import (
+	"io"
+	"strconv"
+	"sync"
+	"unicode/utf8"
+)
+
+// bufferPool holds the buffers of the WriteTo and WriteJSON methods, so that writing many Pokémon or teams reuses them.
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, 2048)
+		return &b
+	},
+}
+
+// writeBuffered writes to w what appendTo appends to a pooled buffer.
+func writeBuffered(w io.Writer, appendTo func(dst []byte) ([]byte, error)) (int64, error) {
+	buf := bufferPool.Get().(*[]byte)
+	defer bufferPool.Put(buf)
+	b, err := appendTo((*buf)[:0])
+	if err != nil {
+		return 0, err
+	}
+	*buf = b
+	n, err := w.Write(b)
+	return int64(n), err
+}
+
+// AppendJSON appends the JSON encoding of the receiver to dst, byte for byte as ToJson writes it, and returns the extended buffer.
+func (p Pokemon) AppendJSON(dst []byte) []byte {
+	dst = append(dst, `{"name":`...)
+	dst = appendJSONString(dst, p.Name)
+	dst = append(dst, `,"nickname":`...)
+	dst = appendJSONString(dst, p.Nickname)
+	dst = append(dst, `,"gender":`...)
+	dst = appendJSONString(dst, p.Gender)
+	dst = append(dst, `,"item":`...)
+	dst = appendJSONString(dst, p.Item)
+	dst = append(dst, `,"ability":`...)
+	dst = appendJSONString(dst, p.Ability)
+	dst = append(dst, `,"level":`...)
+	dst = strconv.AppendInt(dst, int64(p.Level), 10)
+	dst = append(dst, `,"shiny":`...)
+	dst = strconv.AppendBool(dst, p.Shiny)
+	dst = append(dst, `,"happiness":`...)
+	dst = strconv.AppendInt(dst, int64(p.Happiness), 10)
+	// the fields below are omitted when empty, like their JSON tags tell
+	if len(p.Pokeball) > 0 {
+		dst = append(dst, `,"pokeball":`...)
+		dst = appendJSONString(dst, p.Pokeball)
+	}
+	if len(p.HiddenPowerType) > 0 {
+		dst = append(dst, `,"hpType":`...)
+		dst = appendJSONString(dst, p.HiddenPowerType)
+	}
+	if p.DynamaxLevel != 0 {
+		dst = append(dst, `,"dynamaxLevel":`...)
+		dst = strconv.AppendInt(dst, int64(p.DynamaxLevel), 10)
+	}
+	if p.Gigantamax {
+		dst = append(dst, `,"gigantamax":true`...)
+	}
+	if len(p.TeraType) > 0 {
+		dst = append(dst, `,"teraType":`...)
+		dst = appendJSONString(dst, p.TeraType)
+	}
+	dst = append(dst, `,"nature":`...)
+	dst = appendJSONString(dst, p.Nature)
+	dst = append(dst, `,"evs":`...)
+	dst = p.Evs.appendJSON(dst)
+	dst = append(dst, `,"ivs":`...)
+	dst = p.Ivs.appendJSON(dst)
+	dst = append(dst, `,"moves":`...)
+	if p.Moves == nil {
+		dst = append(dst, "null"...)
+	} else {
+		dst = append(dst, '[')
+		for i, move := range p.Moves {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = appendJSONString(dst, move)
+		}
+		dst = append(dst, ']')
+	}
+	return append(dst, '}')
+}
+
+// WriteJSON writes the JSON encoding of the receiver like ToJson writes it.
+func (p Pokemon) WriteJSON(w io.Writer) (int64, error) {
+	return writeBuffered(w, func(dst []byte) ([]byte, error) {
+		return p.AppendJSON(dst), nil
+	})
+}
+
+// AppendJSON appends the JSON encoding of the receiver to dst, byte for byte as ToJson writes it, and returns the extended buffer.
+func (t Team) AppendJSON(dst []byte) []byte {
+	// the fields are omitted when empty, thus each one but the first written is preceded by a comma
+	dst = append(dst, '{')
+	n := len(dst)
+	if len(t.Name) > 0 {
+		dst = append(dst, `"name":`...)
+		dst = appendJSONString(dst, t.Name)
+	}
+	if len(t.Format) > 0 {
+		dst = appendJSONField(dst, n, `"format":`)
+		dst = appendJSONString(dst, t.Format)
+	}
+	if len(t.Folder) > 0 {
+		dst = appendJSONField(dst, n, `"folder":`)
+		dst = appendJSONString(dst, t.Folder)
+	}
+	if len(t.Pokemon) > 0 {
+		dst = appendJSONField(dst, n, `"pokemon":[`)
+		for i, p := range t.Pokemon {
+			if i > 0 {
+				dst = append(dst, ',')
+			}
+			dst = p.AppendJSON(dst)
+		}
+		dst = append(dst, ']')
+	}
+	return append(dst, '}')
+}
+
+// appendJSONField appends the name of a field of an object starting at dst[n], preceded by a comma unless it is the first field.
+func appendJSONField(dst []byte, n int, name string) []byte {
+	if len(dst) > n {
+		dst = append(dst, ',')
+	}
+	return append(dst, name...)
+}
+
+// WriteJSON writes the JSON encoding of the receiver like ToJson writes it.
+func (t Team) WriteJSON(w io.Writer) (int64, error) {
+	return writeBuffered(w, func(dst []byte) ([]byte, error) {
+		return t.AppendJSON(dst), nil
+	})
+}
+
+func (s Stats) appendJSON(dst []byte) []byte {
+	dst = append(dst, `{"hp":`...)
+	dst = strconv.AppendInt(dst, int64(s.Hp), 10)
+	dst = append(dst, `,"atk":`...)
+	dst = strconv.AppendInt(dst, int64(s.Atk), 10)
+	dst = append(dst, `,"def":`...)
+	dst = strconv.AppendInt(dst, int64(s.Def), 10)
+	dst = append(dst, `,"spa":`...)
+	dst = strconv.AppendInt(dst, int64(s.Spa), 10)
+	dst = append(dst, `,"spd":`...)
+	dst = strconv.AppendInt(dst, int64(s.Spd), 10)
+	dst = append(dst, `,"spe":`...)
+	dst = strconv.AppendInt(dst, int64(s.Spe), 10)
+	return append(dst, '}')
+}
+
+// appendJSONString appends s as a JSON string escaped like the standard library does with HTML escaping:
+// quotes, backslashes, control characters, "<", ">", "&", U+2028 and U+2029 are escaped, and invalid UTF-8 becomes U+FFFD.
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	dst = append(dst, '"')
+	start := 0
+	for i := 0; i < len(s); {
+		if c := s[i]; c < utf8.RuneSelf {
+			if c >= 0x20 && c != '"' && c != '\\' && c != '<' && c != '>' && c != '&' {
+				i++
+				continue
+			}
+			dst = append(dst, s[start:i]...)
+			switch c {
+			case '"', '\\':
+				dst = append(dst, '\\', c)
+			case '\n':
+				dst = append(dst, '\\', 'n')
+			case '\r':
+				dst = append(dst, '\\', 'r')
+			case '\t':
+				dst = append(dst, '\\', 't')
+			default:
+				dst = append(dst, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xF])
+			}
+			i++
+			start = i
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, `\ufffd`...)
+		case r == '\u2028' || r == '\u2029':
+			dst = append(dst, s[start:i]...)
+			dst = append(dst, '\\', 'u', '2', '0', '2', hex[r&0xF])
+		default:
+			i += size
+			continue
+		}
+		i += size
+		start = i
+	}
+	dst = append(dst, s[start:]...)
+	return append(dst, '"')
+}
@@ -0,0 +1,183 @@
+package koffing
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePokemon_AppendShowdown() {
+	var p Pokemon
+	_ = p.FromShowdown(koffingSet)
+	buf := make([]byte, 0, 1024)
+	for _, nature := range []string{"Bold", "Calm"} {
+		p.Nature = nature
+		buf, _ = p.AppendShowdown(buf[:0])
+		// Showdown ends every line with two spaces, which are trimmed here to fit in this comment
+		fmt.Print(strings.ReplaceAll(string(buf), "  \n", "\n"))
+	}
+	// Output:
+	// Smogon (Koffing) (F) @ Eviolite
+	// Ability: Levitate
+	// Level: 5
+	// EVs: 36 HP / 236 Def / 236 SpD
+	// Bold Nature
+	// - Will-O-Wisp
+	// - Pain Split
+	// Smogon (Koffing) (F) @ Eviolite
+	// Ability: Levitate
+	// Level: 5
+	// EVs: 36 HP / 236 Def / 236 SpD
+	// Calm Nature
+	// - Will-O-Wisp
+	// - Pain Split
+}
+
+// exportTeams returns the teams of testdata/export.
+func exportTeams(t testing.TB) []Team {
+	files, err := filepath.Glob(filepath.Join("testdata", "export", "*.txt"))
+	assert.NoError(t, err)
+	var teams []Team
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		c := TeamCollection{}
+		assert.NoError(t, c.FromShowdown(string(b)))
+		teams = append(teams, c.Teams...)
+	}
+	assert.NotEmpty(t, teams)
+	return teams
+}
+
+func TestPokemon_AppendShowdown(t *testing.T) {
+	t.Parallel()
+	for _, team := range exportTeams(t) {
+		for _, p := range team.Pokemon {
+			want, err := p.ToShowdown()
+			assert.NoError(t, err)
+			got, err := p.AppendShowdown([]byte("prefix"))
+			assert.NoError(t, err)
+			assert.Equal(t, "prefix"+want, string(got))
+			var w bytes.Buffer
+			n, err := p.WriteTo(&w)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(want)), n)
+			assert.Equal(t, want, w.String())
+		}
+	}
+	// dst is kept on failure
+	got, err := Pokemon{}.AppendShowdown([]byte("prefix"))
+	assert.Error(t, err)
+	assert.Equal(t, "prefix", string(got))
+	n, err := Pokemon{}.WriteTo(io.Discard)
+	assert.Error(t, err)
+	assert.Zero(t, n)
+}
+
+func TestTeam_AppendShowdown(t *testing.T) {
+	t.Parallel()
+	for _, team := range exportTeams(t) {
+		want, err := team.ToShowdown()
+		assert.NoError(t, err)
+		got, err := team.AppendShowdown([]byte("prefix"))
+		assert.NoError(t, err)
+		assert.Equal(t, "prefix"+want, string(got))
+		var w bytes.Buffer
+		n, err := team.WriteTo(&w)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(want)), n)
+		assert.Equal(t, want, w.String())
+	}
+	got, err := Team{Name: "Broken", Pokemon: []Pokemon{{}}}.AppendShowdown([]byte("prefix"))
+	assert.Error(t, err)
+	assert.Equal(t, "prefix", string(got))
+}
+
+func TestPokemon_AppendJSON(t *testing.T) {
+	t.Parallel()
+	pokemon := []Pokemon{
+		{},
+		{Name: "Koffing", Moves: []string{}},
+		{
+			Name: "Koffing", Nickname: "<Gas> & \"Smog\" \\ \t\n\r\x00\x1f\x7f", Item: "\xff\xfeBerry", Ability: "Levitate  ",
+			Pokeball: "Poke Ball", HiddenPowerType: "Fire", DynamaxLevel: 5, Gigantamax: true, TeraType: "Poison", Nature: "Bold",
+			Level: -1, Shiny: true, Happiness: 70, Evs: Stats{Hp: 252, Spe: -4}, Moves: []string{"Haze", "瓦斯弹", ""},
+		},
+	}
+	for _, team := range exportTeams(t) {
+		pokemon = append(pokemon, team.Pokemon...)
+	}
+	for _, p := range pokemon {
+		want, err := p.ToJson()
+		assert.NoError(t, err)
+		assert.Equal(t, "prefix"+want, string(p.AppendJSON([]byte("prefix"))))
+		var w bytes.Buffer
+		n, err := p.WriteJSON(&w)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(want)), n)
+		assert.Equal(t, want, w.String())
+	}
+}
+
+func TestTeam_AppendJSON(t *testing.T) {
+	t.Parallel()
+	teams := append(exportTeams(t), Team{}, Team{Format: "gen9ou"}, Team{Folder: "<Folder>", Pokemon: []Pokemon{{Name: "Koffing"}}})
+	for _, team := range teams {
+		want, err := team.ToJson()
+		assert.NoError(t, err)
+		assert.Equal(t, "prefix"+want, string(team.AppendJSON([]byte("prefix"))))
+		var w bytes.Buffer
+		n, err := team.WriteJSON(&w)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(len(want)), n)
+		assert.Equal(t, want, w.String())
+	}
+}
+
+func TestTeam_AppendShowdown_allocs(t *testing.T) {
+	team := exportTeams(t)[0]
+	buf := make([]byte, 0, 64*1024)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = team.AppendShowdown(buf[:0])
+		buf = team.AppendJSON(buf[:0])
+	})
+	assert.Zero(t, allocs)
+	if raceEnabled {
+		return
+	}
+	// the pooled buffers are reused
+	allocs = testing.AllocsPerRun(100, func() {
+		_, _ = team.WriteTo(io.Discard)
+		_, _ = team.WriteJSON(io.Discard)
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkTeam_AppendShowdown(b *testing.B) {
+	team := exportTeams(b)[0]
+	buf, _ := team.AppendShowdown(nil)
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var err error
+		if buf, err = team.AppendShowdown(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkTeam_AppendJSON(b *testing.B) {
+	team := exportTeams(b)[0]
+	buf := team.AppendJSON(nil)
+	b.SetBytes(int64(len(buf)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		buf = team.AppendJSON(buf[:0])
+	}
+}
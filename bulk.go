@@ -0,0 +1,73 @@
+package koffing
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// BulkOptions controls how ParseAll parses many pastes.
+type BulkOptions struct {
+	// ParseOptions apply to each paste, e.g. its limits.
+	ParseOptions
+	// Workers is the number of pastes parsed at the same time. Zero or less means runtime.GOMAXPROCS(0).
+	Workers int
+}
+
+// ParseResult is the result of parsing one paste of ParseAll.
+type ParseResult struct {
+	// Team is the parsed team, which is the zero value if Err is set.
+	Team Team
+	// Warnings are the unrecognized lines of the paste, like FromShowdownWithOptions returns them.
+	Warnings []*ParseError
+	// Err is a *ParseError if the paste cannot be parsed, or the error of the context if it was canceled before the paste was parsed.
+	Err error
+}
+
+// ParseAll parses each input like Team.FromShowdownWithOptions does, using up to opts.Workers goroutines,
+// and returns the results in the order of the inputs. Once ctx is done, the inputs not started yet fail with ctx.Err(),
+// while those being parsed are completed. The goroutines share no state but the index of the next input to parse.
+func ParseAll(ctx context.Context, inputs []string, opts BulkOptions) []ParseResult {
+	results := make([]ParseResult, len(inputs))
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(inputs) {
+		workers = len(inputs)
+	}
+	var (
+		next int64 = -1 // the index of the last input taken by a worker
+		wg   sync.WaitGroup
+	)
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(atomic.AddInt64(&next, 1))
+				if i >= len(inputs) {
+					return
+				}
+				// each worker writes only the results of the inputs it takes
+				results[i] = parseOne(ctx, inputs[i], opts.ParseOptions)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// parseOne parses an input of ParseAll unless ctx is done.
+func parseOne(ctx context.Context, input string, opts ParseOptions) ParseResult {
+	if err := ctx.Err(); err != nil {
+		return ParseResult{Err: err}
+	}
+	var t Team
+	warnings, err := t.FromShowdownWithOptions(input, opts)
+	if err != nil {
+		return ParseResult{Warnings: warnings, Err: err}
+	}
+	return ParseResult{Team: t, Warnings: warnings}
+}
@@ -0,0 +1,113 @@
+package koffing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseAll() {
+	inputs := []string{
+		"=== [gen9ou] Gas ===\n\nKoffing\nAbility: Levitate\n- Haze",
+		"(Koffing)\nAbility: Levitate\n- Haze",
+		"Weezing @ Black Sludge\nAbility: Neutralizing Gas\n- Will-O-Wisp",
+	}
+	for i, r := range ParseAll(context.Background(), inputs, BulkOptions{Workers: 2}) {
+		if r.Err != nil {
+			fmt.Println(i, "error:", r.Err)
+			continue
+		}
+		fmt.Println(i, r.Team.Name, r.Team.Pokemon[0].Name)
+	}
+	// Output:
+	// 0 Gas Koffing
+	// 1 error: team 0, pokemon 0, line 1, column 1: invalid name: (Koffing)
+	// 2  Weezing
+}
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+	var inputs []string
+	for _, team := range exportTeams(t) {
+		s, err := team.ToShowdown()
+		assert.NoError(t, err)
+		inputs = append(inputs, s, "@ Leftovers\n", s+"Unknown line\n")
+	}
+	// the results are the ones of parsing the inputs one after another
+	want := make([]ParseResult, len(inputs))
+	for i, input := range inputs {
+		var team Team
+		warnings, err := team.FromShowdownWithOptions(input, ParseOptions{MaxPokemon: 6})
+		if err != nil {
+			team = Team{}
+		}
+		want[i] = ParseResult{Team: team, Warnings: warnings, Err: err}
+	}
+	for _, workers := range []int{0, 1, 3, 100} {
+		got := ParseAll(context.Background(), inputs, BulkOptions{ParseOptions: ParseOptions{MaxPokemon: 6}, Workers: workers})
+		assert.Equal(t, want, got, workers)
+	}
+	assert.Empty(t, ParseAll(context.Background(), nil, BulkOptions{}))
+}
+
+func TestParseAll_canceled(t *testing.T) {
+	t.Parallel()
+	inputs := []string{koffingSet, koffingSet, koffingSet}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, r := range ParseAll(ctx, inputs, BulkOptions{}) {
+		assert.True(t, errors.Is(r.Err, context.Canceled))
+		assert.Empty(t, r.Team.Pokemon)
+	}
+
+	// the context is done after the first inputs are started
+	inputs = make([]string, 100)
+	for i := range inputs {
+		inputs[i] = koffingSet
+	}
+	for _, workers := range []int{1, 4} {
+		ctx := &cancelAfter{Context: context.Background(), n: 10}
+		parsed := 0
+		for _, r := range ParseAll(ctx, inputs, BulkOptions{Workers: workers}) {
+			if r.Err == nil {
+				parsed++
+				assert.Equal(t, "Koffing", r.Team.Pokemon[0].Name)
+			} else {
+				assert.True(t, errors.Is(r.Err, context.Canceled))
+			}
+		}
+		assert.Equal(t, 10, parsed, workers)
+	}
+}
+
+// cancelAfter is a context which is canceled after its Err method is called n times.
+type cancelAfter struct {
+	context.Context
+	n int64
+}
+
+func (c *cancelAfter) Err() error {
+	if atomic.AddInt64(&c.n, -1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func BenchmarkParseAll(b *testing.B) {
+	team := exportTeams(b)[0]
+	s, err := team.ToShowdown()
+	if err != nil {
+		b.Fatal(err)
+	}
+	inputs := strings.Split(strings.Repeat(s+"\x00", 1000), "\x00")[:1000]
+	b.SetBytes(int64(len(s) * len(inputs)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		ParseAll(context.Background(), inputs, BulkOptions{})
+	}
+}
@@ -0,0 +1,97 @@
+package koffing
+
+import (
+	"fmt"
+)
+
+// TeamCollection contains a list of Teams, such as the "Backup all teams" dump of the Teambuilder on Pokémon Showdown.
+type TeamCollection struct {
+	Teams []Team `json:"teams"`
+}
+
+// FromJson parses the JSON-encoded team collection and stores the result in the pointer receiver.
+func (c *TeamCollection) FromJson(j string) error {
+	return json.Unmarshal([]byte(j), c)
+}
+
+// ToJson returns the JSON encoding of the receiver.
+func (c TeamCollection) ToJson() (string, error) {
+	return json.MarshalToString(c)
+}
+
+// FromShowdown parses a Showdown backup with one or more team headers and stores the result in the pointer receiver.
+// Each header line (`=== [format] Folder/Name ===`, where the format tag and folder are optional) starts a new Team.
+// Sets that appear before the first header form a Team without name.
+// Unrecognized lines are ignored. The returned error is a *ParseError if the text cannot be parsed.
+func (c *TeamCollection) FromShowdown(s string) error {
+	_, err := c.FromShowdownWithOptions(s, ParseOptions{})
+	return err
+}
+
+// FromShowdownWithOptions is like FromShowdown, but parses with opts.
+// It returns the unrecognized lines as warnings unless opts.Strict is set, in which case they are errors.
+func (c *TeamCollection) FromShowdownWithOptions(s string, opts ParseOptions) (warnings []*ParseError, err error) {
+	ps := newParser(opts)
+	if err := ps.checkInput(s); err != nil {
+		return ps.result(err)
+	}
+	groups := groupByTeamHeader(splitBlocks(s))
+	c.Teams = make([]Team, 0, len(groups))
+	for i, group := range groups {
+		ps.teamIndex = i
+		var t Team
+		if err := t.fromBlocks(ps, group); err != nil {
+			return ps.result(err)
+		}
+		c.Teams = append(c.Teams, t)
+	}
+	return ps.result(nil)
+}
+
+// ToShowdown returns the Showdown backup text of the receiver, in the layout of "Backup all teams" of the Teambuilder.
+// Every team requires a name to write its header.
+func (c TeamCollection) ToShowdown() (string, error) {
+	return c.ToShowdownWithOptions(ShowdownOptions{})
+}
+
+// ToShowdownWithOptions is like ToShowdown, but writes the Pokémon with opts.
+func (c TeamCollection) ToShowdownWithOptions(opts ShowdownOptions) (string, error) {
+	var showdown []byte
+	for i, team := range c.Teams {
+		if len(team.Name) == 0 {
+			return "", fmt.Errorf("failed to export a Team to Showdown: index: %d, error: team name is required", i)
+		}
+		var err error
+		if showdown, err = team.appendShowdown(showdown, opts); err != nil {
+			return "", fmt.Errorf("failed to export a Team to Showdown: index: %d, error: %w", i, err)
+		}
+		showdown = append(showdown, '\n')
+	}
+	return string(showdown), nil
+}
+
+// Validate essentially validates each Team in this TeamCollection.
+func (c TeamCollection) Validate() error {
+	if len(c.Teams) == 0 {
+		return fmt.Errorf("empty teams")
+	}
+	for i, team := range c.Teams {
+		if err := team.Validate(); err != nil {
+			return fmt.Errorf("found an invalid Team: index: %d, error: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// groupByTeamHeader groups blocks into teams, each of which starts with a team header block
+// except the blocks before the first header.
+func groupByTeamHeader(blocks [][]sourceLine) [][][]sourceLine {
+	groups := make([][][]sourceLine, 0, 1)
+	for _, block := range blocks {
+		if len(groups) == 0 || isHeaderBlock(block) {
+			groups = append(groups, nil)
+		}
+		groups[len(groups)-1] = append(groups[len(groups)-1], block)
+	}
+	return groups
+}
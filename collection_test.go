@@ -0,0 +1,136 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const backup = `=== [gen8vgc2021] VGC/Charizard Rain ===
+
+Charizard-Gmax @ Wacan Berry
+Ability: Solar Power
+Level: 50
+EVs: 4 HP / 252 SpA / 252 Spe
+Timid Nature
+IVs: 0 Atk
+- Blast Burn
+- Hurricane
+- Ancient Power
+- Protect
+
+Venusaur-Gmax @ Coba Berry
+Ability: Chlorophyll
+Level: 50
+EVs: 156 HP / 4 Def / 252 SpA / 4 SpD / 92 Spe
+Modest Nature
+IVs: 0 Atk
+- Frenzy Plant
+- Sludge Bomb
+- Earth Power
+- Sleep Powder
+
+
+=== [gen7] Folder 1/Sub Folder/Example Team ===
+
+Smogon (Koffing) (F) @ Eviolite
+Level: 5
+Ability: Levitate
+EVs: 36 HP / 236 Def / 236 SpD
+Bold Nature
+- Will-O-Wisp
+- Pain Split
+
+
+=== Untitled 1 ===
+
+Tapu Fini @ Sitrus Berry
+Ability: Misty Surge
+EVs: 252 HP / 68 Def / 4 SpA / 116 SpD / 68 Spe
+Calm Nature
+IVs: 0 Atk
+- Moonblast
+- Icy Wind
+- Haze
+- Nature's Madness
+`
+
+func ExampleTeamCollection_FromShowdown() {
+	c := new(TeamCollection)
+	_ = c.FromShowdown(backup)
+	for _, t := range c.Teams {
+		fmt.Printf("format: %q, folder: %q, name: %q, pokemon: %d\n", t.Format, t.Folder, t.Name, len(t.Pokemon))
+	}
+	// Output: format: "gen8vgc2021", folder: "VGC", name: "Charizard Rain", pokemon: 2
+	// format: "gen7", folder: "Folder 1/Sub Folder", name: "Example Team", pokemon: 1
+	// format: "", folder: "", name: "Untitled 1", pokemon: 1
+}
+
+func TestTeamCollection_FromShowdown(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		s         string
+		wantTeams int
+		wantErr   bool
+	}{
+		{name: "backup", s: backup, wantTeams: 3},
+		{name: "CRLF", s: "=== [gen8] A ===\r\n\r\n" + "Koffing\r\nAbility: Levitate\r\nBold Nature\r\n- Haze\r\n\r\n=== [gen8] B ===\r\n", wantTeams: 2},
+		{name: "sets before the first header", s: "Koffing\nAbility: Levitate\nBold Nature\n- Haze\n\n=== [gen8] B ===\n", wantTeams: 2},
+		{name: "empty", s: " \n ", wantTeams: 0},
+		{name: "invalid set", s: "=== [gen8] A ===\n\n @ Sitrus Berry\nAbility: Misty Surge\nCalm Nature\n- Moonblast\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := new(TeamCollection)
+			err := c.FromShowdown(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, c.Teams, tt.wantTeams)
+			}
+		})
+	}
+}
+
+func TestTeamCollection_ToShowdown(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromShowdown(backup))
+	s, err := c.ToShowdown()
+	assert.NoError(t, err)
+
+	// the exported text should be parsed into the same collection
+	roundTrip := new(TeamCollection)
+	assert.NoError(t, roundTrip.FromShowdown(s))
+	assert.Equal(t, c, roundTrip)
+
+	c.Teams[1].Name = ""
+	_, err = c.ToShowdown()
+	assert.Error(t, err)
+}
+
+func TestTeamCollection_Json(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromShowdown(backup))
+	j, err := c.ToJson()
+	assert.NoError(t, err)
+
+	roundTrip := new(TeamCollection)
+	assert.NoError(t, roundTrip.FromJson(j))
+	assert.Equal(t, c, roundTrip)
+	assert.Error(t, roundTrip.FromJson(`"teams": []`))
+}
+
+func TestTeamCollection_Validate(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.Error(t, c.Validate())
+	assert.NoError(t, c.FromShowdown(backup))
+	assert.NoError(t, c.Validate())
+	c.Teams[2].Pokemon = nil
+	assert.Error(t, c.Validate())
+}
@@ -0,0 +1,366 @@
+package koffing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LineKind classifies a line of Showdown text.
+type LineKind int
+
+// The kinds of the lines of a set are declared in the order Showdown exports them.
+const (
+	// LineUnknown is a line that cannot be recognized, e.g. a comment or a typo.
+	LineUnknown LineKind = iota
+	LineBlank
+	LineHeader
+	LineName
+	LineAbility
+	LineLevel
+	LineShiny
+	LineHappiness
+	LinePokeball
+	LineHiddenPower
+	LineDynamaxLevel
+	LineGigantamax
+	LineTeraType
+	LineEVs
+	LineNature
+	LineIVs
+	LineMove
+)
+
+// fieldFormats maps the kinds of the lines holding a single value to the format of a new line.
+var fieldFormats = map[LineKind]string{
+	LineAbility:      "Ability: %s",
+	LineLevel:        "Level: %s",
+	LineShiny:        "Shiny: %s",
+	LineHappiness:    "Happiness: %s",
+	LinePokeball:     "Pokeball: %s",
+	LineHiddenPower:  "Hidden Power: %s",
+	LineDynamaxLevel: "Dynamax Level: %s",
+	LineGigantamax:   "Gigantamax: %s",
+	LineTeraType:     "Tera Type: %s",
+	LineNature:       "%s Nature",
+}
+
+// classifyLine returns the kind of a trimmed line of a set other than the name line.
+func classifyLine(text string) LineKind {
+	kind, _ := scanLine(text)
+	return kind
+}
+
+// Document is a lossless syntax tree of Showdown text. It records every line, including blank and unknown ones,
+// with its indentation and line break, so that String returns the input byte for byte until it is edited,
+// and edits only rewrite the lines they touch.
+type Document struct {
+	Lines []*Line
+}
+
+// Line is a line of a Document.
+type Line struct {
+	Kind LineKind
+	// Raw is the line without the line break.
+	Raw string
+	// EOL is the line break, i.e. "\n", "\r\n", or "" for the last line.
+	EOL string
+}
+
+// Set is a Pokémon set of a Document, i.e. a name line and the non-blank lines below it.
+type Set struct {
+	doc  *Document
+	name *Line
+}
+
+// ParseDocument returns the syntax tree of Showdown text. It never fails, since unrecognized lines are kept as LineUnknown.
+func ParseDocument(s string) *Document {
+	d := &Document{}
+	inSet := false
+	for len(s) > 0 {
+		line := &Line{Raw: s}
+		s = ""
+		if i := strings.IndexByte(line.Raw, '\n'); i >= 0 {
+			line.Raw, line.EOL, s = line.Raw[:i], "\n", line.Raw[i+1:]
+			if strings.HasSuffix(line.Raw, "\r") {
+				line.Raw, line.EOL = line.Raw[:len(line.Raw)-1], "\r\n"
+			}
+		}
+		switch text := line.Text(); {
+		case len(text) == 0:
+			line.Kind, inSet = LineBlank, false
+		case isHeaderLine(text):
+			line.Kind, inSet = LineHeader, false
+		case !inSet:
+			line.Kind, inSet = LineName, true
+		default:
+			line.Kind = classifyLine(text)
+		}
+		d.Lines = append(d.Lines, line)
+	}
+	return d
+}
+
+// String returns the text of the Document.
+func (d *Document) String() string {
+	var b strings.Builder
+	for _, line := range d.Lines {
+		b.WriteString(line.Raw)
+		b.WriteString(line.EOL)
+	}
+	return b.String()
+}
+
+// Sets returns the Pokémon sets of the Document in order.
+func (d *Document) Sets() []*Set {
+	var sets []*Set
+	for _, line := range d.Lines {
+		if line.Kind == LineName {
+			sets = append(sets, &Set{doc: d, name: line})
+		}
+	}
+	return sets
+}
+
+// index returns the index of line in d.Lines, or -1 if it is not there.
+func (d *Document) index(line *Line) int {
+	for i, l := range d.Lines {
+		if l == line {
+			return i
+		}
+	}
+	return -1
+}
+
+// Text returns the line without surrounding spaces.
+func (l *Line) Text() string {
+	return strings.TrimSpace(l.Raw)
+}
+
+// indent returns the leading spaces of the line.
+func (l *Line) indent() string {
+	return l.Raw[:len(l.Raw)-len(strings.TrimLeftFunc(l.Raw, unicode.IsSpace))]
+}
+
+// Value returns the value of a line holding a single value, e.g. "Levitate" of "Ability: Levitate",
+// the move of a move line, or the item of a name line. It returns "" for other lines.
+func (l *Line) Value() string {
+	start, end, ok := l.valueSpan()
+	if !ok {
+		return ""
+	}
+	return l.Raw[start:end]
+}
+
+// valueSpan returns the position of the value of the line in Raw.
+func (l *Line) valueSpan() (start, end int, ok bool) {
+	offset := len(l.indent())
+	var value span
+	if l.Kind == LineName {
+		value = tokenizeNameLine(l.Text()).item
+	} else if kind, v := scanLine(l.Text()); kind == l.Kind && kind != LineEVs && kind != LineIVs {
+		value = v
+	}
+	if value.end == 0 {
+		return 0, 0, false
+	}
+	return offset + value.start, offset + value.end, true
+}
+
+// Lines returns the lines of the set, starting with its name line.
+func (s *Set) Lines() []*Line {
+	i := s.doc.index(s.name)
+	if i < 0 {
+		return nil
+	}
+	j := i + 1
+	for j < len(s.doc.Lines) && s.doc.Lines[j].Kind != LineBlank && s.doc.Lines[j].Kind != LineHeader {
+		j++
+	}
+	return s.doc.Lines[i:j]
+}
+
+// Pokemon parses the set leniently into a Pokemon, with the generation of the format in the header of its team if any,
+// e.g. "EVs: 252 Spc" sets both SpA and SpD below "=== [gen1ou] RBY ===".
+func (s *Set) Pokemon() (*Pokemon, error) {
+	i := s.doc.index(s.name)
+	lines := make([]sourceLine, 0, 10)
+	for j, line := range s.Lines() {
+		lines = append(lines, sourceLine{text: line.Text(), raw: line.Raw, num: i + j + 1, col: len(line.indent()) + 1})
+	}
+	ps := newParser(ParseOptions{})
+	for j := i - 1; j >= 0; j-- {
+		if line := s.doc.Lines[j]; line.Kind == LineHeader {
+			var t Team
+			t.parseHeader(line.Text())
+			ps.setFormat(t.Format)
+			break
+		}
+	}
+	p := &Pokemon{}
+	if err := p.fromLines(ps, lines); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// SetItem sets the item on the name line, or removes it if item is empty.
+func (s *Set) SetItem(item string) {
+	start, end, ok := s.name.valueSpan()
+	switch {
+	case ok && len(item) > 0:
+		s.name.Raw = s.name.Raw[:start] + item + s.name.Raw[end:]
+	case ok:
+		at := strings.LastIndexByte(s.name.Raw[:start], '@')
+		s.name.Raw = strings.TrimRightFunc(s.name.Raw[:at], unicode.IsSpace) + s.name.Raw[end:]
+	case len(item) > 0:
+		text := strings.TrimRightFunc(s.name.Raw, unicode.IsSpace)
+		s.name.Raw = text + " @ " + item + s.name.Raw[len(text):]
+	}
+}
+
+// SetField sets the value of a line holding a single value, like LineAbility or LineNature,
+// adding the line in the Showdown order if the set does not have one. An empty value removes the line.
+func (s *Set) SetField(kind LineKind, value string) error {
+	format, ok := fieldFormats[kind]
+	if !ok {
+		return fmt.Errorf("line kind %d does not hold a single value", kind)
+	}
+	line := s.field(kind)
+	if len(value) == 0 {
+		if line != nil {
+			s.remove(line)
+		}
+		return nil
+	}
+	text := fmt.Sprintf(format, value)
+	if classifyLine(text) != kind {
+		return fmt.Errorf("invalid value: %s", value)
+	}
+	if line == nil {
+		s.insert(kind, text)
+		return nil
+	}
+	start, end, _ := line.valueSpan()
+	line.Raw = line.Raw[:start] + value + line.Raw[end:]
+	return nil
+}
+
+// SetEV sets an EV on the EVs line, keeping the other stats as they are written. A zero EV is removed.
+func (s *Set) SetEV(stat string, value int) error {
+	return s.setStat(LineEVs, stat, value, 0)
+}
+
+// SetIV sets an IV on the IVs line, keeping the other stats as they are written. An IV of 31 is removed.
+func (s *Set) SetIV(stat string, value int) error {
+	return s.setStat(LineIVs, stat, value, 31)
+}
+
+// setStat sets a stat on the EVs or IVs line, where a stat with the default value is omitted.
+func (s *Set) setStat(kind LineKind, stat string, value, defaultValue int) error {
+	label, ok := statLabel(stat)
+	if !ok {
+		return fmt.Errorf("unknown stat: %s", stat)
+	}
+	prefix := "EVs"
+	if kind == LineIVs {
+		prefix = "IVs"
+	}
+	line := s.field(kind)
+	if line == nil {
+		if value != defaultValue {
+			s.insert(kind, fmt.Sprintf("%s: %d %s", prefix, value, label))
+		}
+		return nil
+	}
+	offset := len(line.indent())
+	_, v := scanLine(line.Text())
+	start, end := offset+v.start, offset+v.end
+	stats := line.Raw[start:end]
+	parts := strings.Split(stats, "/")
+	found := false
+	for i := 0; i < len(parts); i++ {
+		fields := strings.Fields(parts[i])
+		if len(fields) < 2 {
+			continue
+		}
+		if l, ok := statLabel(strings.Join(fields[1:], " ")); !ok || l != label {
+			continue
+		}
+		found = true
+		if value == defaultValue {
+			parts = append(parts[:i], parts[i+1:]...)
+			i--
+			continue
+		}
+		n := strings.Index(parts[i], fields[0])
+		parts[i] = parts[i][:n] + strconv.Itoa(value) + parts[i][n+len(fields[0]):]
+	}
+	stats = strings.TrimSpace(strings.Join(parts, "/"))
+	if !found && value != defaultValue {
+		entry := strconv.Itoa(value) + " " + label
+		switch {
+		case len(stats) == 0:
+			stats = entry
+		case strings.Contains(stats, "/") && !strings.Contains(stats, " / "):
+			stats += "/" + entry
+		default:
+			stats += " / " + entry
+		}
+	}
+	if len(stats) == 0 {
+		s.remove(line)
+		return nil
+	}
+	line.Raw = line.Raw[:start] + stats + line.Raw[end:]
+	return nil
+}
+
+// field returns the first line of the given kind in the set, or nil.
+func (s *Set) field(kind LineKind) *Line {
+	for _, line := range s.Lines() {
+		if line.Kind == kind {
+			return line
+		}
+	}
+	return nil
+}
+
+// insert adds a line of the given kind after the last line of the set which Showdown exports before it.
+func (s *Set) insert(kind LineKind, text string) {
+	lines := s.Lines()
+	after := 0
+	for i, line := range lines {
+		if line.Kind != LineUnknown && line.Kind <= kind {
+			after = i
+		}
+	}
+	indent := lines[0].indent()
+	if len(lines) > 1 {
+		indent = lines[1].indent()
+	}
+	eol := s.name.EOL
+	if len(eol) == 0 {
+		eol = "\n"
+	}
+	prev := lines[after]
+	line := &Line{Kind: kind, Raw: indent + text, EOL: prev.EOL}
+	if len(prev.EOL) == 0 {
+		prev.EOL, line.EOL = eol, ""
+	}
+	i := s.doc.index(prev) + 1
+	s.doc.Lines = append(s.doc.Lines[:i], append([]*Line{line}, s.doc.Lines[i:]...)...)
+}
+
+// remove deletes a line other than the name line from the set.
+func (s *Set) remove(line *Line) {
+	i := s.doc.index(line)
+	if i < 0 || line == s.name {
+		return
+	}
+	if len(line.EOL) == 0 && i > 0 {
+		s.doc.Lines[i-1].EOL = ""
+	}
+	s.doc.Lines = append(s.doc.Lines[:i], s.doc.Lines[i+1:]...)
+}
@@ -0,0 +1,228 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const userPaste = "=== [gen8] Rain ===\r\n\r\n" +
+	"Pelipper @ Damp Rock  \r\n" +
+	"  Ability: Drizzle\r\n" +
+	"  // lead\r\n" +
+	"  EVs: 248 HP/8 Def/252 SpD\r\n" +
+	"  Bold Nature\r\n" +
+	"  - Scald\r\n" +
+	"  - U-turn\r\n\r\n" +
+	"Barraskewda\r\n" +
+	"Ability: Swift Swim\r\n" +
+	"Adamant Nature\r\n" +
+	"- Liquidation"
+
+func ExampleParseDocument() {
+	doc := ParseDocument("Koffing @ Eviolite\n# a comment\nAbility: Levitate\nBold Nature\n- Haze\n")
+	set := doc.Sets()[0]
+	set.SetItem("Black Sludge")
+	_ = set.SetEV("HP", 252)
+	fmt.Print(doc)
+	// Output: Koffing @ Black Sludge
+	// # a comment
+	// Ability: Levitate
+	// EVs: 252 HP
+	// Bold Nature
+	// - Haze
+}
+
+func TestParseDocument(t *testing.T) {
+	t.Parallel()
+	for _, s := range []string{"", "\n", userPaste, userPaste + "\n\n\n", backup, storage, typoSet, "\r\n  \t\r\nKoffing\r"} {
+		assert.Equal(t, s, ParseDocument(s).String())
+	}
+
+	doc := ParseDocument(userPaste)
+	var kinds []LineKind
+	for _, line := range doc.Lines {
+		kinds = append(kinds, line.Kind)
+	}
+	assert.Equal(t, []LineKind{
+		LineHeader, LineBlank,
+		LineName, LineAbility, LineUnknown, LineEVs, LineNature, LineMove, LineMove, LineBlank,
+		LineName, LineAbility, LineNature, LineMove,
+	}, kinds)
+	assert.Equal(t, "\r\n", doc.Lines[0].EOL)
+	assert.Equal(t, "", doc.Lines[len(doc.Lines)-1].EOL)
+	assert.Equal(t, "Damp Rock", doc.Lines[2].Value())
+	assert.Equal(t, "Drizzle", doc.Lines[3].Value())
+	assert.Equal(t, "U-turn", doc.Lines[8].Value())
+	assert.Equal(t, "", doc.Lines[4].Value())
+	assert.Len(t, doc.Sets(), 2)
+	assert.Len(t, doc.Sets()[0].Lines(), 7)
+}
+
+func TestSet_Pokemon(t *testing.T) {
+	t.Parallel()
+	doc := ParseDocument("=== [gen1ou] RBY ===\n\nChansey\nEVs: 252 Spc\n- Seismic Toss\n\n" +
+		"=== [gen3ou] ADV ===\n\nBlissey\nEVs: 252 Spc\n- Seismic Toss\n")
+	var evs []Stats
+	for _, set := range doc.Sets() {
+		p, err := set.Pokemon()
+		assert.NoError(t, err)
+		evs = append(evs, p.Evs)
+	}
+	// each set is parsed with the generation of its own header
+	assert.Equal(t, []Stats{{Spa: 252, Spd: 252}, {Spa: 252}}, evs)
+
+	p, err := ParseDocument("Chansey\nEVs: 252 Spc\n- Seismic Toss").Sets()[0].Pokemon()
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{Spa: 252}, p.Evs)
+}
+
+func TestSet_SetItem(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		item string
+		want string
+	}{
+		{name: "replace", item: "Leftovers", want: "Pelipper @ Leftovers  \r\n"},
+		{name: "remove", item: "", want: "Pelipper  \r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseDocument(userPaste)
+			doc.Sets()[0].SetItem(tt.item)
+			assert.Equal(t, userPaste[:len("=== [gen8] Rain ===\r\n\r\n")]+tt.want+userPaste[len("=== [gen8] Rain ===\r\n\r\nPelipper @ Damp Rock  \r\n"):], doc.String())
+		})
+	}
+	doc := ParseDocument(userPaste)
+	doc.Sets()[1].SetItem("Choice Band")
+	assert.Contains(t, doc.String(), "\r\nBarraskewda @ Choice Band\r\nAbility: Swift Swim")
+}
+
+func TestSet_SetField(t *testing.T) {
+	t.Parallel()
+	doc := ParseDocument(userPaste)
+	sets := doc.Sets()
+	assert.NoError(t, sets[0].SetField(LineAbility, "Rain Dish"))
+	assert.NoError(t, sets[0].SetField(LineTeraType, "Ground"))
+	assert.NoError(t, sets[1].SetField(LineLevel, "50"))
+	assert.NoError(t, sets[1].SetField(LineTeraType, "Water"))
+	assert.NoError(t, sets[1].SetField(LineNature, "Jolly"))
+	assert.NoError(t, sets[1].SetField(LineShiny, "Yes"))
+	assert.NoError(t, sets[1].SetField(LineShiny, ""))
+	assert.Error(t, sets[1].SetField(LineLevel, "fifty"))
+	assert.Error(t, sets[1].SetField(LineMove, "Aqua Jet"))
+	assert.Equal(t, "=== [gen8] Rain ===\r\n\r\n"+
+		"Pelipper @ Damp Rock  \r\n"+
+		"  Ability: Rain Dish\r\n"+
+		"  Tera Type: Ground\r\n"+
+		"  // lead\r\n"+
+		"  EVs: 248 HP/8 Def/252 SpD\r\n"+
+		"  Bold Nature\r\n"+
+		"  - Scald\r\n"+
+		"  - U-turn\r\n\r\n"+
+		"Barraskewda\r\n"+
+		"Ability: Swift Swim\r\n"+
+		"Level: 50\r\n"+
+		"Tera Type: Water\r\n"+
+		"Jolly Nature\r\n"+
+		"- Liquidation", doc.String())
+
+	p, err := sets[1].Pokemon()
+	assert.NoError(t, err)
+	assert.Equal(t, 50, p.Level)
+	assert.Equal(t, "Jolly", p.Nature)
+	assert.Equal(t, "Water", p.TeraType)
+
+	// a new line at the end of the input gets the line break of the set
+	doc = ParseDocument("Koffing\nAbility: Levitate\n- Haze")
+	assert.NoError(t, doc.Sets()[0].SetField(LineNature, "Bold"))
+	assert.NoError(t, doc.Sets()[0].SetField(LineLevel, "5"))
+	assert.Equal(t, "Koffing\nAbility: Levitate\nLevel: 5\nBold Nature\n- Haze", doc.String())
+	assert.NoError(t, doc.Sets()[0].SetField(LineAbility, ""))
+	assert.Equal(t, "Koffing\nLevel: 5\nBold Nature\n- Haze", doc.String())
+}
+
+func TestSet_SetEV(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		stat  string
+		value int
+		want  string
+	}{
+		{name: "replace", stat: "Def", value: 4, want: "  EVs: 248 HP/4 Def/252 SpD\r\n"},
+		{name: "alias", stat: "Special Defense", value: 244, want: "  EVs: 248 HP/8 Def/244 SpD\r\n"},
+		{name: "add", stat: "Spe", value: 8, want: "  EVs: 248 HP/8 Def/252 SpD/8 Spe\r\n"},
+		{name: "remove first", stat: "HP", value: 0, want: "  EVs: 8 Def/252 SpD\r\n"},
+		{name: "remove last", stat: "SpD", value: 0, want: "  EVs: 248 HP/8 Def\r\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := ParseDocument(userPaste)
+			assert.NoError(t, doc.Sets()[0].SetEV(tt.stat, tt.value))
+			assert.Equal(t, doc.Lines[5].Raw+doc.Lines[5].EOL, tt.want)
+			doc.Lines[5] = ParseDocument(userPaste).Lines[5]
+			assert.Equal(t, userPaste, doc.String())
+		})
+	}
+
+	doc := ParseDocument(userPaste)
+	set := doc.Sets()[0]
+	assert.Error(t, set.SetEV("Luck", 4))
+	for _, stat := range []string{"HP", "Def", "SpD"} {
+		assert.NoError(t, set.SetEV(stat, 0))
+	}
+	assert.NotContains(t, doc.String(), "EVs")
+	assert.Len(t, set.Lines(), 6)
+
+	assert.NoError(t, set.SetIV("Atk", 0))
+	assert.NoError(t, set.SetIV("Spe", 0))
+	assert.NoError(t, set.SetIV("Atk", 31))
+	assert.Contains(t, doc.String(), "  Bold Nature\r\n  IVs: 0 Spe\r\n  - Scald\r\n")
+	p, err := set.Pokemon()
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{Hp: 31, Atk: 31, Def: 31, Spa: 31, Spd: 31, Spe: 0}, p.Ivs)
+}
+
+func FuzzParseDocument(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s)
+	}
+	f.Add(userPaste)
+	f.Fuzz(func(t *testing.T, s string) {
+		doc := ParseDocument(s)
+		if doc.String() != s {
+			t.Fatalf("round trip changed %q into %q", s, doc.String())
+		}
+		for _, set := range doc.Sets() {
+			set.SetItem("Leftovers")
+			_ = set.SetField(LineNature, "Bold")
+			_ = set.SetEV("HP", 4)
+			_ = set.SetIV("Spe", 31)
+			_, _ = set.Pokemon()
+		}
+	})
+}
+
+func FuzzSet_edits(f *testing.F) {
+	f.Add(userPaste, "Leftovers", uint8(LineNature), "Bold", "HP", 4, 0)
+	f.Add("Koffing\nEVs: 4 HP\n- Haze", "", uint8(LineAbility), "Levitate\n- Haze", "Spc", -1, 32)
+	f.Add("Koffing @ Eviolite", "@", uint8(LineEVs), "", "", 252, 31)
+	f.Fuzz(func(t *testing.T, s, item string, kind uint8, value, stat string, ev, iv int) {
+		doc := ParseDocument(s)
+		for _, set := range doc.Sets() {
+			set.SetItem(item)
+			_ = set.SetField(LineKind(kind), value)
+			_ = set.SetEV(stat, ev)
+			_ = set.SetIV(stat, iv)
+			_, _ = set.Pokemon()
+		}
+		// the edited document is still lossless
+		edited := doc.String()
+		if got := ParseDocument(edited).String(); got != edited {
+			t.Fatalf("round trip changed %q into %q", edited, got)
+		}
+	})
+}
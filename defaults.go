@@ -0,0 +1,79 @@
+package koffing
+
+import "strings"
+
+// FormatDefaults are the values Showdown assumes in a format for the fields a set leaves out.
+type FormatDefaults struct {
+	// Level is the level of sets without a Level line, e.g. 50 in VGC and 5 in Little Cup.
+	Level int `json:"level"`
+	// Happiness is the happiness of sets without a Happiness line, which is fixed to 70 in Let's Go.
+	Happiness int `json:"happiness"`
+	// DynamaxLevel is the dynamax level of sets without a Dynamax Level line.
+	DynamaxLevel int `json:"dynamaxLevel"`
+	// Evs are the EVs of sets without an EVs line, i.e. 252 in gens 1 and 2.
+	Evs Stats `json:"evs"`
+}
+
+// DefaultsFor returns the defaults of a Showdown format ID like "gen9vgc2024regg".
+// Unknown formats, including the empty one, get the defaults of the Teambuilder, e.g. level 100.
+func DefaultsFor(format string) FormatDefaults {
+	return defaultsFor(formatGen(format), format)
+}
+
+// defaultsFor returns the defaults of the format in generation gen, which may differ from the one of the format ID.
+func defaultsFor(gen int, format string) FormatDefaults {
+	d := FormatDefaults{Level: 100, Happiness: 255, DynamaxLevel: 10}
+	if gen == 1 || gen == 2 {
+		d.Evs = Stats{Hp: 252, Atk: 252, Def: 252, Spa: 252, Spd: 252, Spe: 252}
+	}
+	tier := strings.ToLower(format)
+	if strings.HasPrefix(tier, "gen") {
+		tier = strings.TrimLeft(tier[len("gen"):], "0123456789")
+	}
+	switch {
+	case tier == "lc" || strings.HasPrefix(tier, "lc") || strings.HasSuffix(tier, "lc"):
+		d.Level = 5
+	case strings.Contains(tier, "vgc") || strings.HasPrefix(tier, "bss") ||
+		strings.HasPrefix(tier, "battlestadium") || strings.HasPrefix(tier, "battlespot"):
+		d.Level = 50
+	case strings.HasPrefix(tier, "letsgo"):
+		d.Happiness = 70
+	}
+	return d
+}
+
+// Effective returns a copy of the receiver whose fields left unset are filled with the defaults d,
+// e.g. a Level of 0 becomes d.Level and EVs of 0 become d.Evs. Since parsing sets the happiness to 255 like the Teambuilder,
+// a happiness of 255 becomes d.Happiness, e.g. 70 in Let's Go.
+// The receiver keeps the raw values, which are the ones written by ToShowdown, so parsing never applies the defaults of a format.
+func (p Pokemon) Effective(d FormatDefaults) Pokemon {
+	if p.Level == 0 {
+		p.Level = d.Level
+	}
+	if p.Happiness == 255 {
+		p.Happiness = d.Happiness
+	}
+	if p.DynamaxLevel == 0 {
+		p.DynamaxLevel = d.DynamaxLevel
+	}
+	if p.Evs == (Stats{}) {
+		p.Evs = d.Evs
+	}
+	return p
+}
+
+// Defaults returns the defaults of the format of the receiver.
+func (t Team) Defaults() FormatDefaults {
+	return DefaultsFor(t.Format)
+}
+
+// Effective returns a copy of the receiver whose Pokémon are filled with the defaults of its format, see Pokemon.Effective.
+func (t Team) Effective() Team {
+	d := t.Defaults()
+	pokemon := make([]Pokemon, len(t.Pokemon))
+	for i, p := range t.Pokemon {
+		pokemon[i] = p.Effective(d)
+	}
+	t.Pokemon = pokemon
+	return t
+}
@@ -0,0 +1,95 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleTeam_Effective() {
+	team := Team{}
+	_ = team.FromShowdown(`=== [gen9vgc2024regg] Doubles ===
+
+Amoonguss @ Rocky Helmet
+Ability: Regenerator
+Tera Type: Water
+- Spore
+- Rage Powder
+- Pollen Puff
+- Protect
+`)
+	fmt.Println(team.Pokemon[0].Level, team.Effective().Pokemon[0].Level)
+	// Output: 0 50
+}
+
+func TestDefaultsFor(t *testing.T) {
+	t.Parallel()
+	retroEvs := Stats{Hp: 252, Atk: 252, Def: 252, Spa: 252, Spd: 252, Spe: 252}
+	tests := []struct {
+		format string
+		want   FormatDefaults
+	}{
+		{format: "", want: FormatDefaults{Level: 100, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen9ou", want: FormatDefaults{Level: 100, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen9vgc2024regg", want: FormatDefaults{Level: 50, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen8vgc2021", want: FormatDefaults{Level: 50, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen9battlestadiumsingles", want: FormatDefaults{Level: 50, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen8bss", want: FormatDefaults{Level: 50, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen7battlespotdoubles", want: FormatDefaults{Level: 50, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen9lc", want: FormatDefaults{Level: 5, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen7lcuu", want: FormatDefaults{Level: 5, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen9doubleslc", want: FormatDefaults{Level: 5, Happiness: 255, DynamaxLevel: 10}},
+		{format: "gen7letsgoou", want: FormatDefaults{Level: 100, Happiness: 70, DynamaxLevel: 10}},
+		{format: "gen2ou", want: FormatDefaults{Level: 100, Happiness: 255, DynamaxLevel: 10, Evs: retroEvs}},
+		{format: "gen1lc", want: FormatDefaults{Level: 5, Happiness: 255, DynamaxLevel: 10, Evs: retroEvs}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DefaultsFor(tt.format), tt.format)
+	}
+}
+
+func TestPokemon_Effective(t *testing.T) {
+	t.Parallel()
+	d := DefaultsFor("gen9lc")
+	assert.Equal(t, Pokemon{Name: "Mudbray", Level: 5, DynamaxLevel: 10}, Pokemon{Name: "Mudbray"}.Effective(d))
+	// set values are kept
+	assert.Equal(t, Pokemon{Name: "Mudbray", Level: 4, DynamaxLevel: 3}, Pokemon{Name: "Mudbray", Level: 4, DynamaxLevel: 3}.Effective(d))
+	retro := DefaultsFor("gen2ou")
+	assert.Equal(t, Stats{252, 252, 252, 252, 252, 252}, Pokemon{Name: "Snorlax", Happiness: 255}.Effective(retro).Evs)
+	assert.Equal(t, Stats{Hp: 4}, Pokemon{Name: "Snorlax", Evs: Stats{Hp: 4}}.Effective(retro).Evs)
+}
+
+func TestTeam_Effective(t *testing.T) {
+	t.Parallel()
+	team := Team{}
+	assert.NoError(t, team.FromShowdown(`=== [gen7letsgoou] Kanto ===
+
+Pikachu-Starter @ Light Ball
+- Thunderbolt
+- Protect
+
+Eevee-Starter
+Level: 90
+Happiness: 70
+- Bouncy Bubble
+- Protect
+`))
+	// the happiness and the level are kept raw
+	assert.Equal(t, 255, team.Pokemon[0].Happiness)
+	assert.Equal(t, 0, team.Pokemon[0].Level)
+	effective := team.Effective()
+	assert.Equal(t, 70, effective.Pokemon[0].Happiness)
+	assert.Equal(t, 100, effective.Pokemon[0].Level)
+	assert.Equal(t, 90, effective.Pokemon[1].Level)
+	assert.Equal(t, 0, team.Pokemon[0].Level, "the receiver is unchanged")
+	assert.Equal(t, 255, team.Pokemon[0].Happiness, "the receiver is unchanged")
+
+	// the export of the raw values is the text of Showdown
+	s, err := team.ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "Pikachu-Starter @ Light Ball  \n- Thunderbolt  \n")
+	s, err = effective.ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "Eevee-Starter  \nLevel: 90  \nHappiness: 70  \n")
+}
@@ -0,0 +1,55 @@
+package dex
+
+// AbilityData is the data of an ability, e.g. Levitate.
+type AbilityData struct {
+	Name string `json:"name"`
+	// ShortDesc is a one-line description of the effect like in the Teambuilder of Pokémon Showdown.
+	ShortDesc string `json:"shortDesc"`
+	// Breakable reports whether the ability is ignored by the moves of a Pokémon with an ability like Mold Breaker,
+	// e.g. Levitate.
+	Breakable bool `json:"-"`
+	// IgnoresAbilities reports whether the moves of the Pokémon ignore the breakable abilities of others, e.g. Mold Breaker.
+	IgnoresAbilities bool `json:"ignoresAbilities,omitempty"`
+	// Weather is the weather set on switch-in as named by Pokémon Showdown,
+	// i.e. "SunnyDay", "RainDance", "Sandstorm" or "Snowscape".
+	Weather string `json:"weather,omitempty"`
+	// Terrain is the terrain set on switch-in, e.g. "Electric Terrain".
+	Terrain string `json:"terrain,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the layout of Pokémon Showdown, in which the flags are set to 1.
+func (d *AbilityData) UnmarshalJSON(data []byte) error {
+	type ability AbilityData
+	var v struct {
+		ability
+		Flags map[string]int `json:"flags"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = AbilityData(v.ability)
+	d.Breakable = v.Flags["breakable"] != 0
+	return nil
+}
+
+// SetsWeather reports whether the ability sets a weather on switch-in, e.g. Drizzle.
+func (d AbilityData) SetsWeather() bool {
+	return len(d.Weather) > 0
+}
+
+// SetsTerrain reports whether the ability sets a terrain on switch-in, e.g. Grassy Surge.
+func (d AbilityData) SetsTerrain() bool {
+	return len(d.Terrain) > 0
+}
+
+var abilityTable = table[AbilityData]{file: "data/abilities.json", name: func(d *AbilityData) string { return d.Name }}
+
+// Ability returns the data of an ability by its name or ID, e.g. "Neutralizing Gas" or "neutralizinggas",
+// or false if it is unknown.
+func Ability(name string) (AbilityData, bool) {
+	d := abilityTable.get(name)
+	if d == nil {
+		return AbilityData{}, false
+	}
+	return *d, true
+}
@@ -0,0 +1,64 @@
+package dex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleAbility() {
+	for _, name := range []string{"Drought", "Mold Breaker", "Levitate"} {
+		ability, _ := Ability(name)
+		fmt.Println(ability.Name, ability.Weather, ability.IgnoresAbilities, ability.Breakable)
+	}
+	// Output:
+	// Drought SunnyDay false false
+	// Mold Breaker  true false
+	// Levitate  false true
+}
+
+func TestAbility(t *testing.T) {
+	t.Parallel()
+	ability, ok := Ability("neutralizinggas")
+	assert.True(t, ok)
+	assert.Equal(t, AbilityData{Name: "Neutralizing Gas", ShortDesc: "While this Pokemon is active, Abilities have no effect."}, ability)
+	assert.False(t, ability.SetsWeather())
+	assert.False(t, ability.SetsTerrain())
+
+	ability, _ = Ability("Snow Warning")
+	assert.Equal(t, "Snowscape", ability.Weather)
+	assert.True(t, ability.SetsWeather())
+	ability, _ = Ability("Hadron Engine")
+	assert.Equal(t, "Electric Terrain", ability.Terrain)
+	assert.True(t, ability.SetsTerrain())
+
+	for _, name := range []string{"Teravolt", "Turboblaze"} {
+		ability, _ := Ability(name)
+		assert.True(t, ability.IgnoresAbilities, name)
+	}
+	ability, _ = Ability("Ice Scales")
+	assert.True(t, ability.Breakable)
+	for _, name := range []string{"Slush Rush", "Iron Fist"} {
+		ability, ok := Ability(name)
+		assert.True(t, ok, name)
+		assert.False(t, ability.Breakable, name)
+	}
+	for _, name := range []string{"", "Wonder Guard"} {
+		_, ok := Ability(name)
+		assert.False(t, ok, name)
+	}
+}
+
+// TestAbility_data checks the consistency of the ability table.
+func TestAbility_data(t *testing.T) {
+	t.Parallel()
+	entries := abilityTable.load()
+	assert.NotEmpty(t, entries)
+	for id, ability := range entries {
+		assert.Equal(t, id, ToID(ability.Name))
+		assert.NotEmpty(t, ability.ShortDesc, ability.Name)
+		assert.Contains(t, []string{"", "SunnyDay", "RainDance", "Sandstorm", "Snowscape"}, ability.Weather, ability.Name)
+		assert.False(t, ability.Breakable && ability.IgnoresAbilities, ability.Name)
+	}
+}
@@ -0,0 +1,105 @@
+// Package dex provides game data of Pokémon embedded in the binary, like the Pokédex of Pokémon Showdown,
+// e.g. dex.Species("Koffing") returns the types, base stats and abilities of Koffing,
+// dex.Move("Will-O-Wisp") returns the type, accuracy and flags of Will-O-Wisp, and dex.Item and dex.Ability
+// return the effects of held items and abilities.
+//
+// The data describe the latest generation, and Gen returns the data as they were in an older one,
+// e.g. dex.Gen(5).Species("Togekiss") is Normal/Flying. The tables of species, moves, items and abilities are in the layout
+// of the data of Pokémon Showdown, and go generate rebuilds them in full from those data, see internal/dexgen.
+// The committed tables are a snapshot of the names of common teams of every generation rather than the whole Pokédex,
+// and lookups of the other names report false.
+//
+// Names are compared by their IDs, see ToID, and Resolve returns the canonical name of a species, item, ability,
+// move or nature, or "did you mean" suggestions if the name is unknown.
+package dex
+
+import (
+	"embed"
+	"fmt"
+	"sync"
+
+	jsoniter "github.com/json-iterator/go"
+)
+
+var json = jsoniter.ConfigCompatibleWithStandardLibrary
+
+//go:generate go run ./internal/dexgen
+
+// Gen is a generation of the games, from 1 to Latest.
+type Gen int
+
+// Latest is the generation described by the data.
+const Latest Gen = 9
+
+// valid reports whether the generation is in range [1, Latest].
+func (g Gen) valid() bool {
+	return g >= 1 && g <= Latest
+}
+
+// dataFiles holds the data tables, which are JSON objects keyed by the IDs of the names in the layout of Pokémon Showdown.
+//
+//go:embed data/*.json
+var dataFiles embed.FS
+
+// table is a data table decoded at its first use.
+type table[T any] struct {
+	file string
+	// name returns the name of an entry.
+	name    func(*T) string
+	once    sync.Once
+	entries map[string]*T
+}
+
+// load decodes the table once and returns its entries keyed by ID.
+func (t *table[T]) load() map[string]*T {
+	t.once.Do(func() {
+		mustDecode(t.file, &t.entries)
+	})
+	return t.entries
+}
+
+// get returns the entry of a name in the table, or nil if there is none.
+func (t *table[T]) get(name string) *T {
+	return t.load()[ToID(name)]
+}
+
+// nameOf returns the name of the entry of an ID.
+func (t *table[T]) nameOf(id string) (string, bool) {
+	if e, ok := t.load()[id]; ok {
+		return t.name(e), true
+	}
+	return "", false
+}
+
+// each calls f with the ID and the name of each entry, in no particular order.
+func (t *table[T]) each(f func(id, name string)) {
+	for id, e := range t.load() {
+		f(id, t.name(e))
+	}
+}
+
+// mustDecode decodes a data file into v, and panics if the embedded data are invalid.
+func mustDecode(file string, v interface{}) {
+	b, err := dataFiles.ReadFile(file)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		panic(fmt.Errorf("invalid data table %s: %w", file, err))
+	}
+}
+
+// ToID returns the ID of a name like Pokémon Showdown does, i.e. its lower-cased ASCII letters and digits,
+// e.g. "mrmime" for "Mr. Mime". Names which only differ in case, spaces and punctuation have the same ID.
+func ToID(name string) string {
+	id := make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c >= 'a' && c <= 'z' || c >= '0' && c <= '9':
+			id = append(id, c)
+		case c >= 'A' && c <= 'Z':
+			id = append(id, c+'a'-'A')
+		}
+	}
+	return string(id)
+}
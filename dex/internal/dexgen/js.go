@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+)
+
+// assignment matches the start of the object literal assigned to the table of a script, after a type annotation if any.
+var assignment = regexp.MustCompile(`=\s*\{`)
+
+// objectJSON returns the object literal of a data file of Pokémon Showdown as JSON.
+// The file may be JSON, a script of the client like `exports.BattleItems = {abilityshield:{name:"Ability Shield"}};`,
+// or a TypeScript file of the server holding data only, like data/mods/gen1/pokedex.ts.
+// Keys are quoted, strings are converted to JSON strings, and comments and trailing commas are dropped.
+// Values which are not JSON literals, like functions, are errors.
+func objectJSON(src []byte) ([]byte, error) {
+	start := bytes.IndexByte(src, '{')
+	if loc := assignment.FindIndex(src); loc != nil && len(bytes.TrimSpace(src[:start])) > 0 {
+		start = loc[1] - 1
+	}
+	if start < 0 {
+		return nil, fmt.Errorf("no object literal")
+	}
+	c := &converter{src: src, pos: start}
+	if err := c.value(); err != nil {
+		return nil, fmt.Errorf("offset %d: %w", c.pos, err)
+	}
+	return c.out, nil
+}
+
+// converter writes JSON while reading a JavaScript literal.
+type converter struct {
+	src []byte
+	pos int
+	out []byte
+}
+
+// skip skips the spaces and comments.
+func (c *converter) skip() {
+	for c.pos < len(c.src) {
+		switch {
+		case c.src[c.pos] == ' ' || c.src[c.pos] == '\t' || c.src[c.pos] == '\n' || c.src[c.pos] == '\r':
+			c.pos++
+		case bytes.HasPrefix(c.src[c.pos:], []byte("//")):
+			end := bytes.IndexByte(c.src[c.pos:], '\n')
+			if end < 0 {
+				c.pos = len(c.src)
+			} else {
+				c.pos += end
+			}
+		case bytes.HasPrefix(c.src[c.pos:], []byte("/*")):
+			end := bytes.Index(c.src[c.pos+2:], []byte("*/"))
+			if end < 0 {
+				c.pos = len(c.src)
+			} else {
+				c.pos += end + 4
+			}
+		default:
+			return
+		}
+	}
+}
+
+// peek returns the next byte after the spaces and comments, or 0 at the end of the source.
+func (c *converter) peek() byte {
+	c.skip()
+	if c.pos == len(c.src) {
+		return 0
+	}
+	return c.src[c.pos]
+}
+
+func (c *converter) value() error {
+	switch b := c.peek(); {
+	case b == '{':
+		return c.object()
+	case b == '[':
+		return c.array()
+	case b == '"' || b == '\'' || b == '`':
+		s, err := c.str()
+		if err != nil {
+			return err
+		}
+		c.out = appendString(c.out, s)
+		return nil
+	case b == '-' || b == '.' || b >= '0' && b <= '9':
+		return c.number()
+	case isIdentStart(b):
+		switch ident := c.ident(); ident {
+		case "true", "false", "null":
+			c.out = append(c.out, ident...)
+		case "undefined":
+			c.out = append(c.out, "null"...)
+		default:
+			return fmt.Errorf("unsupported value %s", ident)
+		}
+		return nil
+	}
+	return fmt.Errorf("unexpected %q", c.peek())
+}
+
+func (c *converter) object() error {
+	c.pos++ // {
+	c.out = append(c.out, '{')
+	for first := true; ; first = false {
+		b := c.peek()
+		if b == '}' {
+			c.pos++
+			c.out = append(c.out, '}')
+			return nil
+		}
+		if !first {
+			if b != ',' {
+				return fmt.Errorf("expected , or } in object")
+			}
+			c.pos++
+			if c.peek() == '}' { // trailing comma
+				continue
+			}
+			c.out = append(c.out, ',')
+		}
+		key, err := c.key()
+		if err != nil {
+			return err
+		}
+		if c.peek() != ':' {
+			return fmt.Errorf("expected : after key %s", key)
+		}
+		c.pos++
+		c.out = appendString(c.out, key)
+		c.out = append(c.out, ':')
+		if err := c.value(); err != nil {
+			return err
+		}
+	}
+}
+
+// key reads a key, which is a string, an identifier or a number.
+func (c *converter) key() (string, error) {
+	switch b := c.peek(); {
+	case b == '"' || b == '\'':
+		return c.str()
+	case isIdentStart(b) || b >= '0' && b <= '9':
+		start := c.pos
+		for c.pos < len(c.src) && (isIdentStart(c.src[c.pos]) || c.src[c.pos] >= '0' && c.src[c.pos] <= '9') {
+			c.pos++
+		}
+		return string(c.src[start:c.pos]), nil
+	}
+	return "", fmt.Errorf("unexpected %q for a key", c.peek())
+}
+
+func (c *converter) array() error {
+	c.pos++ // [
+	c.out = append(c.out, '[')
+	for first := true; ; first = false {
+		b := c.peek()
+		if b == ']' {
+			c.pos++
+			c.out = append(c.out, ']')
+			return nil
+		}
+		if !first {
+			if b != ',' {
+				return fmt.Errorf("expected , or ] in array")
+			}
+			c.pos++
+			if c.peek() == ']' { // trailing comma
+				continue
+			}
+			c.out = append(c.out, ',')
+		}
+		if err := c.value(); err != nil {
+			return err
+		}
+	}
+}
+
+// str reads a string literal quoted with ", ' or `, the last one without substitutions.
+func (c *converter) str() (string, error) {
+	quote := c.src[c.pos]
+	c.pos++
+	var s []byte
+	for c.pos < len(c.src) {
+		b := c.src[c.pos]
+		switch {
+		case b == quote:
+			c.pos++
+			return string(s), nil
+		case b == '$' && quote == '`' && c.pos+1 < len(c.src) && c.src[c.pos+1] == '{':
+			return "", fmt.Errorf("unsupported substitution in a template literal")
+		case b == '\\' && c.pos+1 < len(c.src):
+			c.pos++
+			switch e := c.src[c.pos]; e {
+			case 'n':
+				s = append(s, '\n')
+			case 't':
+				s = append(s, '\t')
+			case 'r':
+				s = append(s, '\r')
+			case 'u':
+				if c.pos+4 >= len(c.src) {
+					return "", fmt.Errorf("invalid escape")
+				}
+				r, err := strconv.ParseUint(string(c.src[c.pos+1:c.pos+5]), 16, 32)
+				if err != nil {
+					return "", err
+				}
+				s = utf8.AppendRune(s, rune(r))
+				c.pos += 4
+			default:
+				s = append(s, e)
+			}
+			c.pos++
+		default:
+			s = append(s, b)
+			c.pos++
+		}
+	}
+	return "", fmt.Errorf("unterminated string")
+}
+
+func (c *converter) number() error {
+	start := c.pos
+	for c.pos < len(c.src) {
+		b := c.src[c.pos]
+		if !(b >= '0' && b <= '9' || b == '.' || b == '-' || b == '+' || b == 'e' || b == 'E') {
+			break
+		}
+		c.pos++
+	}
+	f, err := strconv.ParseFloat(string(c.src[start:c.pos]), 64)
+	if err != nil {
+		return err
+	}
+	c.out = strconv.AppendFloat(c.out, f, 'f', -1, 64)
+	return nil
+}
+
+func (c *converter) ident() string {
+	start := c.pos
+	for c.pos < len(c.src) && (isIdentStart(c.src[c.pos]) || c.src[c.pos] >= '0' && c.src[c.pos] <= '9') {
+		c.pos++
+	}
+	return string(c.src[start:c.pos])
+}
+
+// appendString appends a JSON string.
+func appendString(dst []byte, s string) []byte {
+	b, _ := json.Marshal(s)
+	return append(dst, b...)
+}
+
+func isIdentStart(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b == '_' || b == '$'
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_objectJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{name: "json", src: `{"a": {"b": [1, 2.5, -3]}}`, want: `{"a":{"b":[1,2.5,-3]}}`},
+		{name: "json assignment", src: ` {"a": "b = {c}"}`, want: `{"a":"b = {c}"}`},
+		{name: "client script", src: `exports.BattleItems = {abilityshield:{name:"Ability Shield",num:1881}};`, want: `{"abilityshield":{"name":"Ability Shield","num":1881}}`},
+		{
+			name: "server module",
+			src: "export const Pokedex: import('../../../sim/dex-species').ModdedSpeciesDataTable = {\n" +
+				"\t// comment\n\tmagnemite: {\n\t\tinherit: true,\n\t\ttypes: [\"Electric\"],\n\t},\n\t/* comment */\n};\n",
+			want: `{"magnemite":{"inherit":true,"types":["Electric"]}}`,
+		},
+		{name: "type annotation", src: "export const AbilitiesText: {[k: string]: AbilityText} = {\n\tstench: {name: \"Stench\"},\n};\n", want: `{"stench":{"name":"Stench"}}`},
+		{name: "quotes", src: "{a: 'It\\'s', b: `x\"y`, c: \"\\u00e9\\n\", 'd-e': 'Farfetch’d'}", want: `{"a":"It's","b":"x\"y","c":"é\n","d-e":"Farfetch’d"}`},
+		{name: "literals", src: `{a: true, b: false, c: null, d: undefined, 1: .5}`, want: `{"a":true,"b":false,"c":null,"d":null,"1":0.5}`},
+		{name: "trailing commas", src: `{a: [1, 2,], b: {},}`, want: `{"a":[1,2],"b":{}}`},
+		{name: "empty", src: `{}`, want: `{}`},
+		{name: "no object", src: `exports.x = 1;`, wantErr: true},
+		{name: "function", src: `{onStart(pokemon) {}}`, wantErr: true},
+		{name: "function value", src: `{a: function () {}}`, wantErr: true},
+		{name: "substitution", src: "{a: `${b}`}", wantErr: true},
+		{name: "missing comma", src: `{a: 1 b: 2}`, wantErr: true},
+		{name: "unterminated", src: `{a: "b`, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := objectJSON([]byte(tt.src))
+		if tt.wantErr {
+			assert.Error(t, err, tt.name)
+			continue
+		}
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, tt.want, string(got), tt.name)
+	}
+}
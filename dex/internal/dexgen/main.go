@@ -0,0 +1,126 @@
+// Command dexgen generates the data tables of package dex from the data of Pokémon Showdown.
+//
+// It reads the data of the client, i.e. pokedex.json, moves.json, items.js and abilities.js,
+// and the data of the server which the client does not have, i.e. the descriptions of the abilities in text/abilities.ts
+// and the Pokédex of the older generations in mods/gen1/pokedex.ts to mods/gen8/pokedex.ts.
+// The sources are URLs by default, or directories holding copies of the files:
+//
+//	go run ./internal/dexgen -client path/to/client/data -server path/to/pokemon-showdown/data
+//
+// The tables are written to the directory given by -dst, which is data for go generate in package dex.
+// The natures and the aliases are maintained by hand.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// latest is the generation of the data of the client.
+const latest = 9
+
+// source is a data file of Pokémon Showdown.
+type source struct {
+	// server is true for the files of the server, and false for the ones of the client.
+	server bool
+	name   string
+}
+
+// tables are the generated tables with the sources they are converted from, which convert gets in the same order.
+var tables = []struct {
+	file    string
+	sources []source
+	convert func(src [][]byte) ([]entry, error)
+}{
+	{
+		file:    "species.json",
+		sources: append([]source{{name: "pokedex.json"}}, modSources("pokedex.ts")...),
+		convert: func(src [][]byte) ([]entry, error) { return speciesTable(src[0], src[1:]) },
+	},
+	{
+		file:    "moves.json",
+		sources: []source{{name: "moves.json"}},
+		convert: func(src [][]byte) ([]entry, error) { return moveTable(src[0]) },
+	},
+	{
+		file:    "items.json",
+		sources: []source{{name: "items.js"}},
+		convert: func(src [][]byte) ([]entry, error) { return itemTable(src[0]) },
+	},
+	{
+		file:    "abilities.json",
+		sources: []source{{name: "abilities.js"}, {server: true, name: "text/abilities.ts"}},
+		convert: func(src [][]byte) ([]entry, error) { return abilityTable(src[0], src[1]) },
+	},
+}
+
+// modSources returns the files of the mods of the older generations with the name, from gen 1.
+func modSources(name string) []source {
+	var sources []source
+	for gen := 1; gen < latest; gen++ {
+		sources = append(sources, source{server: true, name: fmt.Sprintf("mods/gen%d/%s", gen, name)})
+	}
+	return sources
+}
+
+func main() {
+	client := flag.String("client", "https://play.pokemonshowdown.com/data/", "URL or directory of the data of the client")
+	server := flag.String("server", "https://raw.githubusercontent.com/smogon/pokemon-showdown/master/data/", "URL or directory of the data of the server")
+	dst := flag.String("dst", "data", "directory of the generated tables")
+	flag.Parse()
+	if err := generate(*client, *server, *dst); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(client, server, dst string) error {
+	for _, t := range tables {
+		src := make([][]byte, len(t.sources))
+		for i, s := range t.sources {
+			base := client
+			if s.server {
+				base = server
+			}
+			b, err := readSource(base, s.name)
+			if err != nil {
+				return fmt.Errorf("%s: %w", s.name, err)
+			}
+			src[i] = b
+		}
+		entries, err := t.convert(src)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.file, err)
+		}
+		b, err := marshalTable(entries)
+		if err != nil {
+			return fmt.Errorf("%s: %w", t.file, err)
+		}
+		if err := os.WriteFile(filepath.Join(dst, t.file), b, 0o644); err != nil {
+			return err
+		}
+		log.Printf("%s: %d entries", t.file, len(entries))
+	}
+	return nil
+}
+
+// readSource reads a file of a base URL or directory.
+func readSource(base, name string) ([]byte, error) {
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return os.ReadFile(filepath.Join(base, filepath.FromSlash(name)))
+	}
+	resp, err := http.Get(strings.TrimSuffix(base, "/") + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
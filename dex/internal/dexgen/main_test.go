@@ -0,0 +1,74 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// writeSources writes the files of the sources of all tables to a client and a server directory,
+// with empty tables for the files which are not given.
+func writeSources(t *testing.T, files map[string]string) (client, server string) {
+	client, server = t.TempDir(), t.TempDir()
+	for _, table := range tables {
+		for _, s := range table.sources {
+			dir := client
+			if s.server {
+				dir = server
+			}
+			data, ok := files[s.name]
+			if !ok {
+				data = "{}"
+			}
+			path := filepath.Join(dir, filepath.FromSlash(s.name))
+			assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+			assert.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+		}
+	}
+	return client, server
+}
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+	client, server := writeSources(t, map[string]string{
+		"pokedex.json":         `{"mew": {"num": 151, "name": "Mew", "types": ["Psychic"], "gender": "N", "baseStats": {"hp": 100, "atk": 100, "def": 100, "spa": 100, "spd": 100, "spe": 100}, "abilities": {"0": "Synchronize"}, "heightm": 0.4, "weightkg": 4}}`,
+		"mods/gen2/pokedex.ts": `export const Pokedex = {mew: {inherit: true, abilities: {0: "No Ability"}}};`,
+	})
+	dst := t.TempDir()
+	assert.NoError(t, generate(client, server, dst))
+	b, err := os.ReadFile(filepath.Join(dst, "species.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+"mew": {"num": 151, "name": "Mew", "types": ["Psychic"], "gender": "N", "baseStats": {"hp": 100, "atk": 100, "def": 100, "spa": 100, "spd": 100, "spe": 100}, "abilities": {"0": "Synchronize"}, "heightm": 0.4, "weightkg": 4, "gen": 1, "past": [{"gen": 2, "abilities": {"0": "No Ability"}}]}
+}
+`, string(b))
+
+	assert.Error(t, generate(client, t.TempDir(), dst))
+	assert.Error(t, generate(client, server, filepath.Join(dst, "missing")))
+	client, server = writeSources(t, map[string]string{"pokedex.json": `exports.BattlePokedex = {mew: {num: "151"}};`})
+	assert.Error(t, generate(client, server, dst))
+}
+
+func Test_readSource(t *testing.T) {
+	t.Parallel()
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/mods/gen1/pokedex.ts" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+	b, err := readSource(ts.URL+"/data/", "mods/gen1/pokedex.ts")
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(b))
+	b, err = readSource(ts.URL+"/data", "mods/gen1/pokedex.ts")
+	assert.NoError(t, err)
+	assert.Equal(t, "{}", string(b))
+	_, err = readSource(ts.URL+"/data/", "pokedex.json")
+	assert.EqualError(t, err, "404 Not Found")
+}
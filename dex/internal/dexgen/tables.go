@@ -0,0 +1,397 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// entry is an entry of a generated table.
+type entry struct {
+	id    string
+	value interface{}
+}
+
+// each calls f with the ID and the JSON of each entry of a table of Pokémon Showdown, in the order of the source.
+func each(src []byte, f func(id string, data []byte) error) error {
+	object, err := objectJSON(src)
+	if err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(object))
+	if _, err := d.Token(); err != nil {
+		return err
+	}
+	for d.More() {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		var data json.RawMessage
+		if err := d.Decode(&data); err != nil {
+			return err
+		}
+		if err := f(token.(string), data); err != nil {
+			return fmt.Errorf("%s: %w", token, err)
+		}
+	}
+	return nil
+}
+
+// nonstandard reports whether an entry only exists in the fan-made formats of Pokémon Showdown, like CAP.
+func nonstandard(isNonstandard string) bool {
+	return isNonstandard == "CAP" || isNonstandard == "Custom"
+}
+
+type stats struct {
+	Hp  int `json:"hp"`
+	Atk int `json:"atk"`
+	Def int `json:"def"`
+	Spa int `json:"spa"`
+	Spd int `json:"spd"`
+	Spe int `json:"spe"`
+}
+
+type abilities struct {
+	Primary   string `json:"0"`
+	Secondary string `json:"1,omitempty"`
+	Hidden    string `json:"H,omitempty"`
+	Special   string `json:"S,omitempty"`
+}
+
+type genderRatio struct {
+	M float64 `json:"M"`
+	F float64 `json:"F"`
+}
+
+// pastSpecies is the data of a species which changed since a generation, as in the mods of the older generations.
+type pastSpecies struct {
+	Gen       int        `json:"gen"`
+	Types     []string   `json:"types,omitempty"`
+	BaseStats *stats     `json:"baseStats,omitempty"`
+	Abilities *abilities `json:"abilities,omitempty"`
+}
+
+type species struct {
+	Num          int           `json:"num"`
+	Name         string        `json:"name"`
+	BaseSpecies  string        `json:"baseSpecies,omitempty"`
+	Forme        string        `json:"forme,omitempty"`
+	Types        []string      `json:"types"`
+	Gender       string        `json:"gender,omitempty"`
+	GenderRatio  *genderRatio  `json:"genderRatio,omitempty"`
+	BaseStats    stats         `json:"baseStats"`
+	Abilities    abilities     `json:"abilities"`
+	HeightM      float64       `json:"heightm"`
+	WeightKg     float64       `json:"weightkg"`
+	Prevo        string        `json:"prevo,omitempty"`
+	Evos         []string      `json:"evos,omitempty"`
+	OtherFormes  []string      `json:"otherFormes,omitempty"`
+	RequiredItem string        `json:"requiredItem,omitempty"`
+	Gen          int           `json:"gen"`
+	Past         []pastSpecies `json:"past,omitempty"`
+}
+
+// speciesTable converts the Pokédex of the latest generation, and the Pokédex mods of the older ones from gen 1,
+// which only hold the data that changed. The past data are read from the newest generation.
+func speciesTable(pokedex []byte, mods [][]byte) ([]entry, error) {
+	var entries []entry
+	index := map[string]*species{}
+	err := each(pokedex, func(id string, data []byte) error {
+		var v struct {
+			species
+			RequiredItems []string `json:"requiredItems"`
+			IsNonstandard string   `json:"isNonstandard"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if v.Num <= 0 || nonstandard(v.IsNonstandard) {
+			return nil
+		}
+		s := v.species
+		if len(s.RequiredItem) == 0 && len(v.RequiredItems) > 0 {
+			s.RequiredItem = v.RequiredItems[0]
+		}
+		if s.Gen == 0 {
+			s.Gen = speciesGen(s.Num, s.Forme)
+		}
+		index[id] = &s
+		entries = append(entries, entry{id: id, value: &s})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for gen := len(mods); gen >= 1; gen-- {
+		err := each(mods[gen-1], func(id string, data []byte) error {
+			s, ok := index[id]
+			if !ok || gen < s.Gen {
+				return nil
+			}
+			past := pastSpecies{Gen: gen}
+			if err := json.Unmarshal(data, &past); err != nil {
+				return err
+			}
+			past.Gen = gen
+			if past.Types != nil || past.BaseStats != nil || past.Abilities != nil {
+				s.Past = append(s.Past, past)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("gen%d: %w", gen, err)
+		}
+	}
+	return entries, nil
+}
+
+// speciesGen returns the generation which introduced a species or forme like Pokémon Showdown does.
+func speciesGen(num int, forme string) int {
+	switch {
+	case num >= 906 || strings.Contains(forme, "Paldea"):
+		return 9
+	case num >= 810 || forme == "Gmax" || forme == "Galar" || forme == "Galar-Zen" || forme == "Hisui":
+		return 8
+	case num >= 722 || strings.HasPrefix(forme, "Alola") || forme == "Starter":
+		return 7
+	case num >= 650 || forme == "Primal" || forme == "Mega" || forme == "Mega-X" || forme == "Mega-Y":
+		return 6
+	case num >= 494:
+		return 5
+	case num >= 387:
+		return 4
+	case num >= 252:
+		return 3
+	case num >= 152:
+		return 2
+	}
+	return 1
+}
+
+// moveFlags are the flags of moves decoded by package dex.
+var moveFlags = []string{"contact", "sound", "punch", "bite", "bullet", "pulse", "slicing", "wind", "powder", "heal", "charge", "recharge"}
+
+type move struct {
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+	Category  string `json:"category"`
+	BasePower int    `json:"basePower"`
+	// Accuracy is true for the moves which never miss.
+	Accuracy interface{}    `json:"accuracy"`
+	PP       int            `json:"pp"`
+	Priority int            `json:"priority"`
+	Target   string         `json:"target"`
+	Flags    map[string]int `json:"flags"`
+}
+
+func moveTable(moves []byte) ([]entry, error) {
+	var entries []entry
+	err := each(moves, func(id string, data []byte) error {
+		var v struct {
+			move
+			IsNonstandard string `json:"isNonstandard"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if nonstandard(v.IsNonstandard) {
+			return nil
+		}
+		m := v.move
+		m.Flags = map[string]int{}
+		for _, flag := range moveFlags {
+			if v.Flags[flag] != 0 {
+				m.Flags[flag] = 1
+			}
+		}
+		entries = append(entries, entry{id: id, value: &m})
+		return nil
+	})
+	return entries, err
+}
+
+type fling struct {
+	BasePower int `json:"basePower"`
+}
+
+type naturalGift struct {
+	BasePower int    `json:"basePower"`
+	Type      string `json:"type"`
+}
+
+type item struct {
+	Name        string       `json:"name"`
+	Fling       *fling       `json:"fling,omitempty"`
+	MegaStone   string       `json:"megaStone,omitempty"`
+	MegaEvolves string       `json:"megaEvolves,omitempty"`
+	ZMove       interface{}  `json:"zMove,omitempty"`
+	ZMoveType   string       `json:"zMoveType,omitempty"`
+	ZMoveFrom   string       `json:"zMoveFrom,omitempty"`
+	ItemUser    []string     `json:"itemUser,omitempty"`
+	OnPlate     string       `json:"onPlate,omitempty"`
+	IsBerry     bool         `json:"isBerry,omitempty"`
+	NaturalGift *naturalGift `json:"naturalGift,omitempty"`
+	IsChoice    bool         `json:"isChoice,omitempty"`
+}
+
+func itemTable(items []byte) ([]entry, error) {
+	var entries []entry
+	err := each(items, func(id string, data []byte) error {
+		var v struct {
+			item
+			// MegaStone is the name of the Mega Evolution, or an object mapping the species to their Mega Evolutions
+			// for the stones of several species.
+			MegaStone     json.RawMessage `json:"megaStone"`
+			IsNonstandard string          `json:"isNonstandard"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if nonstandard(v.IsNonstandard) {
+			return nil
+		}
+		it := v.item
+		if len(v.MegaStone) > 0 {
+			if err := json.Unmarshal(v.MegaStone, &it.MegaStone); err != nil {
+				var megas map[string]string
+				if err := json.Unmarshal(v.MegaStone, &megas); err != nil {
+					return err
+				}
+				for base, mega := range megas {
+					if len(it.MegaStone) == 0 || mega < it.MegaStone {
+						it.MegaEvolves, it.MegaStone = base, mega
+					}
+				}
+			}
+		}
+		if zMove, ok := it.ZMove.(bool); ok && !zMove {
+			it.ZMove = nil
+		}
+		entries = append(entries, entry{id: id, value: &it})
+		return nil
+	})
+	return entries, err
+}
+
+// The effects of abilities which Pokémon Showdown implements in code rather than data.
+var (
+	abilityWeathers = map[string]string{
+		"drizzle": "RainDance", "drought": "SunnyDay", "orichalcumpulse": "SunnyDay",
+		"sandstream": "Sandstorm", "snowwarning": "Snowscape",
+	}
+	abilityTerrains = map[string]string{
+		"electricsurge": "Electric Terrain", "hadronengine": "Electric Terrain", "grassysurge": "Grassy Terrain",
+		"mistysurge": "Misty Terrain", "psychicsurge": "Psychic Terrain",
+	}
+	abilityBreakers = map[string]bool{"moldbreaker": true, "teravolt": true, "turboblaze": true}
+)
+
+type abilityFlags struct {
+	Breakable int `json:"breakable,omitempty"`
+}
+
+type ability struct {
+	Name             string        `json:"name"`
+	ShortDesc        string        `json:"shortDesc"`
+	Flags            *abilityFlags `json:"flags,omitempty"`
+	IgnoresAbilities bool          `json:"ignoresAbilities,omitempty"`
+	Weather          string        `json:"weather,omitempty"`
+	Terrain          string        `json:"terrain,omitempty"`
+}
+
+// abilityTable converts the abilities, whose descriptions are in a separate table of texts.
+func abilityTable(abilities, texts []byte) ([]entry, error) {
+	descs := map[string]string{}
+	err := each(texts, func(id string, data []byte) error {
+		var v struct {
+			ShortDesc string `json:"shortDesc"`
+			Desc      string `json:"desc"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		descs[id] = v.ShortDesc
+		if len(v.ShortDesc) == 0 {
+			descs[id] = v.Desc
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var entries []entry
+	err = each(abilities, func(id string, data []byte) error {
+		var v struct {
+			Name          string         `json:"name"`
+			Flags         map[string]int `json:"flags"`
+			IsNonstandard string         `json:"isNonstandard"`
+		}
+		if err := json.Unmarshal(data, &v); err != nil {
+			return err
+		}
+		if nonstandard(v.IsNonstandard) {
+			return nil
+		}
+		a := ability{
+			Name:             v.Name,
+			ShortDesc:        descs[id],
+			IgnoresAbilities: abilityBreakers[id],
+			Weather:          abilityWeathers[id],
+			Terrain:          abilityTerrains[id],
+		}
+		if len(a.ShortDesc) == 0 {
+			return fmt.Errorf("no description")
+		}
+		if v.Flags["breakable"] != 0 {
+			a.Flags = &abilityFlags{Breakable: 1}
+		}
+		entries = append(entries, entry{id: id, value: &a})
+		return nil
+	})
+	return entries, err
+}
+
+// marshalTable encodes a table in the layout of the files of package dex, i.e. a JSON object with an entry per line.
+func marshalTable(entries []entry) ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString("{\n")
+	for i, e := range entries {
+		var value bytes.Buffer
+		enc := json.NewEncoder(&value)
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(e.value); err != nil {
+			return nil, fmt.Errorf("%s: %w", e.id, err)
+		}
+		b.Write(appendString(nil, e.id))
+		b.WriteString(": ")
+		b.Write(spaced(bytes.TrimSuffix(value.Bytes(), []byte("\n"))))
+		if i < len(entries)-1 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("}\n")
+	return b.Bytes(), nil
+}
+
+// spaced adds a space after the colons and commas of compact JSON, outside of the strings.
+func spaced(compact []byte) []byte {
+	out := make([]byte, 0, len(compact)+len(compact)/8)
+	inString := false
+	for i := 0; i < len(compact); i++ {
+		b := compact[i]
+		out = append(out, b)
+		switch {
+		case inString && b == '\\':
+			i++
+			out = append(out, compact[i])
+		case b == '"':
+			inString = !inString
+		case !inString && (b == ':' || b == ','):
+			out = append(out, ' ')
+		}
+	}
+	return out
+}
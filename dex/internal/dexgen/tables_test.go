@@ -0,0 +1,214 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// marshal returns the lines of the entries of a generated table.
+func marshal(t *testing.T, entries []entry) string {
+	b, err := marshalTable(entries)
+	assert.NoError(t, err)
+	return string(b)
+}
+
+func Test_speciesTable(t *testing.T) {
+	t.Parallel()
+	pokedex := `{
+	"magnemite": {"num": 81, "name": "Magnemite", "types": ["Electric", "Steel"], "gender": "N", "baseStats": {"hp": 25, "atk": 35, "def": 70, "spa": 95, "spd": 55, "spe": 45}, "abilities": {"0": "Magnet Pull", "1": "Sturdy", "H": "Analytic"}, "heightm": 0.3, "weightkg": 6, "color": "Gray", "evos": ["Magneton"], "eggGroups": ["Mineral"]},
+	"zaciancrowned": {"num": 888, "name": "Zacian-Crowned", "baseSpecies": "Zacian", "forme": "Crowned", "types": ["Fairy", "Steel"], "gender": "N", "baseStats": {"hp": 92, "atk": 150, "def": 115, "spa": 80, "spd": 115, "spe": 148}, "abilities": {"0": "Intrepid Sword"}, "heightm": 2.8, "weightkg": 355, "requiredItems": ["Rusted Sword"], "changesFrom": "Zacian"},
+	"syclant": {"num": -1, "name": "Syclant", "types": ["Ice", "Bug"], "baseStats": {"hp": 70, "atk": 116, "def": 70, "spa": 114, "spd": 64, "spe": 121}, "abilities": {"0": "Compound Eyes"}, "heightm": 1.7, "weightkg": 52, "isNonstandard": "CAP"},
+	"pokestarsmeargle": {"num": 235, "name": "Pokestar Smeargle", "types": ["Normal"], "baseStats": {"hp": 55, "atk": 20, "def": 35, "spa": 20, "spd": 45, "spe": 75}, "abilities": {"0": "Own Tempo"}, "heightm": 1.2, "weightkg": 58, "isNonstandard": "Custom"},
+	"raichualola": {"num": 26, "name": "Raichu-Alola", "baseSpecies": "Raichu", "forme": "Alola", "types": ["Electric", "Psychic"], "baseStats": {"hp": 60, "atk": 85, "def": 50, "spa": 95, "spd": 85, "spe": 110}, "abilities": {"0": "Surge Surfer"}, "heightm": 0.7, "weightkg": 21, "prevo": "Pikachu", "isNonstandard": "Past"}
+}`
+	mods := make([][]byte, latest-1)
+	for i := range mods {
+		mods[i] = []byte(`export const Pokedex = {};`)
+	}
+	mods[0] = []byte(`export const Pokedex = {
+	magnemite: {
+		inherit: true,
+		types: ["Electric"],
+		baseStats: {hp: 25, atk: 35, def: 70, spa: 95, spd: 95, spe: 45},
+	},
+	raichualola: {
+		inherit: true,
+		baseStats: {hp: 60, atk: 85, def: 50, spa: 95, spd: 95, spe: 110},
+	},
+};`)
+	mods[2] = []byte(`export const Pokedex = {magnemite: {inherit: true, abilities: {0: "Magnet Pull", 1: "Sturdy"}}};`)
+	mods[7] = []byte(`export const Pokedex = {zaciancrowned: {inherit: true, baseStats: {hp: 92, atk: 170, def: 115, spa: 80, spd: 115, spe: 148}}, magnemite: {inherit: true}};`)
+	entries, err := speciesTable([]byte(pokedex), mods)
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+"magnemite": {"num": 81, "name": "Magnemite", "types": ["Electric", "Steel"], "gender": "N", "baseStats": {"hp": 25, "atk": 35, "def": 70, "spa": 95, "spd": 55, "spe": 45}, "abilities": {"0": "Magnet Pull", "1": "Sturdy", "H": "Analytic"}, "heightm": 0.3, "weightkg": 6, "evos": ["Magneton"], "gen": 1, "past": [{"gen": 3, "abilities": {"0": "Magnet Pull", "1": "Sturdy"}}, {"gen": 1, "types": ["Electric"], "baseStats": {"hp": 25, "atk": 35, "def": 70, "spa": 95, "spd": 95, "spe": 45}}]},
+"zaciancrowned": {"num": 888, "name": "Zacian-Crowned", "baseSpecies": "Zacian", "forme": "Crowned", "types": ["Fairy", "Steel"], "gender": "N", "baseStats": {"hp": 92, "atk": 150, "def": 115, "spa": 80, "spd": 115, "spe": 148}, "abilities": {"0": "Intrepid Sword"}, "heightm": 2.8, "weightkg": 355, "requiredItem": "Rusted Sword", "gen": 8, "past": [{"gen": 8, "baseStats": {"hp": 92, "atk": 170, "def": 115, "spa": 80, "spd": 115, "spe": 148}}]},
+"raichualola": {"num": 26, "name": "Raichu-Alola", "baseSpecies": "Raichu", "forme": "Alola", "types": ["Electric", "Psychic"], "baseStats": {"hp": 60, "atk": 85, "def": 50, "spa": 95, "spd": 85, "spe": 110}, "abilities": {"0": "Surge Surfer"}, "heightm": 0.7, "weightkg": 21, "prevo": "Pikachu", "gen": 7}
+}
+`, marshal(t, entries))
+
+	_, err = speciesTable([]byte(`{"bulbasaur": {"num": "1"}}`), nil)
+	assert.Error(t, err)
+	_, err = speciesTable([]byte(pokedex), [][]byte{[]byte(`{magnemite: {types: ["Electric",],},}`)})
+	assert.NoError(t, err)
+	_, err = speciesTable([]byte(pokedex), [][]byte{[]byte(`{magnemite: {types: "Electric"}}`)})
+	assert.Error(t, err)
+}
+
+func Test_speciesGen(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		num   int
+		forme string
+		want  int
+	}{
+		{1, "", 1},
+		{151, "", 1},
+		{152, "", 2},
+		{252, "", 3},
+		{387, "", 4},
+		{494, "", 5},
+		{650, "", 6},
+		{3, "Mega", 6},
+		{6, "Mega-X", 6},
+		{382, "Primal", 6},
+		{722, "", 7},
+		{26, "Alola", 7},
+		{25, "Alola", 7},
+		{105, "Alola-Totem", 7},
+		{25, "Starter", 7},
+		{810, "", 8},
+		{3, "Gmax", 8},
+		{110, "Galar", 8},
+		{555, "Galar-Zen", 8},
+		{58, "Hisui", 8},
+		{906, "", 9},
+		{128, "Paldea-Combat", 9},
+		{194, "Paldea", 9},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, speciesGen(tt.num, tt.forme), "%d %s", tt.num, tt.forme)
+	}
+}
+
+func Test_moveTable(t *testing.T) {
+	t.Parallel()
+	moves := `{
+	"aurasphere": {"num": 396, "accuracy": true, "basePower": 80, "category": "Special", "name": "Aura Sphere", "pp": 20, "priority": 0, "flags": {"protect": 1, "mirror": 1, "distance": 1, "metronome": 1, "bullet": 1, "pulse": 1}, "target": "any", "type": "Fighting"},
+	"crunch": {"num": 242, "accuracy": 100, "basePower": 80, "category": "Physical", "name": "Crunch", "pp": 15, "priority": 0, "flags": {"contact": 1, "protect": 1, "mirror": 1, "metronome": 1, "bite": 1}, "secondary": {"chance": 20, "boosts": {"def": -1}}, "target": "normal", "type": "Dark"},
+	"paleowave": {"num": 0, "accuracy": 100, "basePower": 85, "category": "Special", "name": "Paleo Wave", "pp": 15, "priority": 0, "flags": {"protect": 1, "mirror": 1}, "target": "normal", "type": "Rock", "isNonstandard": "CAP"},
+	"protect": {"num": 182, "accuracy": true, "basePower": 0, "category": "Status", "name": "Protect", "pp": 10, "priority": 4, "flags": {"noassist": 1, "failcopycat": 1}, "stallingMove": true, "volatileStatus": "protect", "target": "self", "type": "Normal"}
+}`
+	entries, err := moveTable([]byte(moves))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+"aurasphere": {"name": "Aura Sphere", "type": "Fighting", "category": "Special", "basePower": 80, "accuracy": true, "pp": 20, "priority": 0, "target": "any", "flags": {"bullet": 1, "pulse": 1}},
+"crunch": {"name": "Crunch", "type": "Dark", "category": "Physical", "basePower": 80, "accuracy": 100, "pp": 15, "priority": 0, "target": "normal", "flags": {"bite": 1, "contact": 1}},
+"protect": {"name": "Protect", "type": "Normal", "category": "Status", "basePower": 0, "accuracy": true, "pp": 10, "priority": 4, "target": "self", "flags": {}}
+}
+`, marshal(t, entries))
+
+	_, err = moveTable([]byte(`{"crunch": {"pp": "15"}}`))
+	assert.Error(t, err)
+}
+
+func Test_itemTable(t *testing.T) {
+	t.Parallel()
+	items := `exports.BattleItems = {
+charizarditex:{name:"Charizardite X",spritenum:585,megaStone:"Charizard-Mega-X",megaEvolves:"Charizard",itemUser:["Charizard"],num:660,gen:6,isNonstandard:"Past"},
+choicescarf:{name:"Choice Scarf",spritenum:69,fling:{basePower:10},isChoice:true,num:287,gen:4},
+crucibellite:{name:"Crucibellite",spritenum:577,megaStone:"Crucibelle-Mega",megaEvolves:"Crucibelle",itemUser:["Crucibelle"],num:-1,gen:6,isNonstandard:"CAP"},
+firiumz:{name:"Firium Z",spritenum:632,onPlate:"Fire",onTakeItem:false,zMove:true,zMoveType:"Fire",forcedForme:"Arceus-Fire",num:777,gen:7,isNonstandard:"Past"},
+leftovers:{name:"Leftovers",spritenum:242,fling:{basePower:10},num:234,gen:2},
+lucarionite:{name:"Lucarionite",spritenum:594,megaStone:{"Lucario":"Lucario-Mega","Lucario-Z":"Lucario-Mega-Z"},itemUser:["Lucario"],num:673,gen:6},
+pikaniumz:{name:"Pikanium Z",spritenum:649,onTakeItem:false,zMove:"Catastropika",zMoveFrom:"Volt Tackle",itemUser:["Pikachu"],num:794,gen:7,isNonstandard:"Past"},
+sitrusberry:{name:"Sitrus Berry",spritenum:448,isBerry:true,naturalGift:{basePower:80,type:"Psychic"},num:158,gen:3},
+};`
+	entries, err := itemTable([]byte(items))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+"charizarditex": {"name": "Charizardite X", "megaStone": "Charizard-Mega-X", "megaEvolves": "Charizard", "itemUser": ["Charizard"]},
+"choicescarf": {"name": "Choice Scarf", "fling": {"basePower": 10}, "isChoice": true},
+"firiumz": {"name": "Firium Z", "zMove": true, "zMoveType": "Fire", "onPlate": "Fire"},
+"leftovers": {"name": "Leftovers", "fling": {"basePower": 10}},
+"lucarionite": {"name": "Lucarionite", "megaStone": "Lucario-Mega", "megaEvolves": "Lucario", "itemUser": ["Lucario"]},
+"pikaniumz": {"name": "Pikanium Z", "zMove": "Catastropika", "zMoveFrom": "Volt Tackle", "itemUser": ["Pikachu"]},
+"sitrusberry": {"name": "Sitrus Berry", "isBerry": true, "naturalGift": {"basePower": 80, "type": "Psychic"}}
+}
+`, marshal(t, entries))
+
+	_, err = itemTable([]byte(`{leftovers: {megaStone: 1}}`))
+	assert.Error(t, err)
+	_, err = itemTable([]byte(`{leftovers: {onResidual(pokemon) {}}}`))
+	assert.Error(t, err)
+}
+
+func Test_abilityTable(t *testing.T) {
+	t.Parallel()
+	abilities := `exports.BattleAbilities = {
+drought:{name:"Drought",rating:4,num:70},
+levitate:{flags:{breakable:1},name:"Levitate",rating:3.5,num:26},
+moldbreaker:{name:"Mold Breaker",rating:3,num:104},
+mountaineer:{isNonstandard:"CAP",name:"Mountaineer",rating:3,num:-2},
+noability:{isNonstandard:"Past",flags:{},name:"No Ability",rating:0.1,num:0},
+psychicsurge:{name:"Psychic Surge",rating:4,num:227},
+};`
+	texts := `export const AbilitiesText: {[k: string]: AbilityText} = {
+	drought: {
+		name: "Drought",
+		desc: "On switch-in, this Pokemon summons Sunny Day. The weather lasts for 5 turns.",
+		shortDesc: "On switch-in, this Pokemon summons Sunny Day.",
+		gen4: {
+			shortDesc: "On switch-in, this Pokemon summons Sunny Day until another weather replaces it.",
+		},
+	},
+	levitate: {
+		name: "Levitate",
+		shortDesc: "This Pokemon is immune to Ground; Gravity/Ingrain/Smack Down/Iron Ball nullify it.",
+	},
+	moldbreaker: {
+		name: "Mold Breaker",
+		shortDesc: "This Pokemon's moves and their effects ignore the Abilities of other Pokemon.",
+		start: "  [POKEMON] breaks the mold!",
+	},
+	noability: {
+		name: "No Ability",
+		desc: "Does nothing.",
+	},
+	psychicsurge: {
+		name: "Psychic Surge",
+		shortDesc: "On switch-in, this Pokemon summons Psychic Terrain.",
+	},
+};`
+	entries, err := abilityTable([]byte(abilities), []byte(texts))
+	assert.NoError(t, err)
+	assert.Equal(t, `{
+"drought": {"name": "Drought", "shortDesc": "On switch-in, this Pokemon summons Sunny Day.", "weather": "SunnyDay"},
+"levitate": {"name": "Levitate", "shortDesc": "This Pokemon is immune to Ground; Gravity/Ingrain/Smack Down/Iron Ball nullify it.", "flags": {"breakable": 1}},
+"moldbreaker": {"name": "Mold Breaker", "shortDesc": "This Pokemon's moves and their effects ignore the Abilities of other Pokemon.", "ignoresAbilities": true},
+"noability": {"name": "No Ability", "shortDesc": "Does nothing."},
+"psychicsurge": {"name": "Psychic Surge", "shortDesc": "On switch-in, this Pokemon summons Psychic Terrain.", "terrain": "Psychic Terrain"}
+}
+`, marshal(t, entries))
+
+	_, err = abilityTable([]byte(`{stench: {name: "Stench"}}`), []byte(texts))
+	assert.EqualError(t, err, "stench: no description")
+	_, err = abilityTable([]byte(abilities), []byte(`{drought: {shortDesc: 1}}`))
+	assert.Error(t, err)
+}
+
+func Test_marshalTable(t *testing.T) {
+	t.Parallel()
+	entries := []entry{
+		{id: "farfetchd", value: map[string]interface{}{"name": "Farfetch’d", "desc": "a, b: <c> & \"d\""}},
+		{id: "empty", value: map[string]interface{}{}},
+	}
+	assert.Equal(t, "{\n"+
+		`"farfetchd": {"desc": "a, b: <c> & \"d\"", "name": "Farfetch’d"},`+"\n"+
+		`"empty": {}`+"\n"+
+		"}\n", marshal(t, entries))
+	assert.Equal(t, "{\n}\n", marshal(t, nil))
+	_, err := marshalTable([]entry{{id: "f", value: func() {}}})
+	assert.Error(t, err)
+}
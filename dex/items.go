@@ -0,0 +1,74 @@
+package dex
+
+// NaturalGift is the type and base power of the move Natural Gift with a Berry.
+type NaturalGift struct {
+	BasePower int    `json:"basePower"`
+	Type      string `json:"type"`
+}
+
+// ItemData is the data of a held item, e.g. Leftovers.
+type ItemData struct {
+	Name string `json:"name"`
+	// Fling is the base power of the move Fling with the item, or 0 if the item cannot be flung, e.g. a mega stone.
+	Fling int `json:"-"`
+	// MegaStone and MegaEvolves are the Mega Evolution of a mega stone and the species it evolves from,
+	// e.g. "Venusaur-Mega" and "Venusaur" for Venusaurite.
+	MegaStone   string `json:"megaStone,omitempty"`
+	MegaEvolves string `json:"megaEvolves,omitempty"`
+	// ZCrystal reports whether the item is a Z-Crystal. A Z-Crystal turns the moves of a type into Z-Moves,
+	// e.g. Firium Z for Fire, or a move of its users into an exclusive Z-Move, e.g. Pikanium Z for Volt Tackle of Pikachu.
+	ZCrystal  bool   `json:"-"`
+	ZMoveType string `json:"zMoveType,omitempty"`
+	// ZMove and ZMoveFrom are the exclusive Z-Move of a Z-Crystal and the move it is made from.
+	ZMove     string `json:"-"`
+	ZMoveFrom string `json:"zMoveFrom,omitempty"`
+	// ItemUser are the species for which the item is made, e.g. the species of a mega stone.
+	ItemUser []string `json:"itemUser,omitempty"`
+	// OnPlate is the type given by a plate to Judgment and Arceus, e.g. Fire for Flame Plate.
+	OnPlate     string      `json:"onPlate,omitempty"`
+	IsBerry     bool        `json:"isBerry,omitempty"`
+	NaturalGift NaturalGift `json:"naturalGift"`
+	// IsChoice reports whether the item locks the holder into its first move, e.g. Choice Scarf.
+	IsChoice bool `json:"isChoice,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the layout of Pokémon Showdown,
+// in which zMove is true for the Z-Crystals of a type and the name of the Z-Move for the exclusive ones.
+func (d *ItemData) UnmarshalJSON(data []byte) error {
+	type item ItemData
+	var v struct {
+		item
+		Fling struct {
+			BasePower int `json:"basePower"`
+		} `json:"fling"`
+		ZMove interface{} `json:"zMove"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = ItemData(v.item)
+	d.Fling = v.Fling.BasePower
+	switch zMove := v.ZMove.(type) {
+	case bool:
+		d.ZCrystal = zMove
+	case string:
+		d.ZCrystal, d.ZMove = true, zMove
+	}
+	return nil
+}
+
+// IsMegaStone reports whether the item is a mega stone.
+func (d ItemData) IsMegaStone() bool {
+	return len(d.MegaStone) > 0
+}
+
+var itemTable = table[ItemData]{file: "data/items.json", name: func(d *ItemData) string { return d.Name }}
+
+// Item returns the data of an item by its name or ID, e.g. "Choice Scarf" or "choicescarf", or false if it is unknown.
+func Item(name string) (ItemData, bool) {
+	d := itemTable.get(name)
+	if d == nil {
+		return ItemData{}, false
+	}
+	return *d, true
+}
@@ -0,0 +1,118 @@
+package dex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleItem() {
+	for _, name := range []string{"Leftovers", "venusaurite", "Firium Z", "Sitrus Berry"} {
+		item, _ := Item(name)
+		switch {
+		case item.IsMegaStone():
+			fmt.Println(item.Name, "evolves", item.MegaEvolves, "into", item.MegaStone)
+		case item.ZCrystal:
+			fmt.Println(item.Name, "turns", item.ZMoveType, "moves into Z-Moves")
+		case item.IsBerry:
+			fmt.Println(item.Name, "gives Natural Gift the type", item.NaturalGift.Type)
+		default:
+			fmt.Println(item.Name, "is flung with a power of", item.Fling)
+		}
+	}
+	// Output:
+	// Leftovers is flung with a power of 10
+	// Venusaurite evolves Venusaur into Venusaur-Mega
+	// Firium Z turns Fire moves into Z-Moves
+	// Sitrus Berry gives Natural Gift the type Psychic
+}
+
+func TestItem(t *testing.T) {
+	t.Parallel()
+	item, ok := Item("Charizardite Y")
+	assert.True(t, ok)
+	assert.Equal(t, ItemData{
+		Name: "Charizardite Y", MegaStone: "Charizard-Mega-Y", MegaEvolves: "Charizard", ItemUser: []string{"Charizard"},
+	}, item)
+	assert.True(t, item.IsMegaStone())
+
+	item, _ = Item("pikaniumz")
+	assert.True(t, item.ZCrystal)
+	assert.Equal(t, "Catastropika", item.ZMove)
+	assert.Equal(t, "Volt Tackle", item.ZMoveFrom)
+	assert.Empty(t, item.ZMoveType)
+	assert.False(t, item.IsMegaStone())
+
+	item, _ = Item("Normalium Z")
+	assert.True(t, item.ZCrystal)
+	assert.Empty(t, item.ZMove)
+	assert.Equal(t, "Normal", item.ZMoveType)
+
+	item, _ = Item("Pixie Plate")
+	assert.Equal(t, "Fairy", item.OnPlate)
+	assert.Equal(t, 90, item.Fling)
+
+	item, _ = Item("Salac Berry")
+	assert.True(t, item.IsBerry)
+	assert.Equal(t, NaturalGift{BasePower: 100, Type: "Fighting"}, item.NaturalGift)
+
+	for _, name := range []string{"Choice Band", "Choice Specs", "Choice Scarf"} {
+		item, _ := Item(name)
+		assert.True(t, item.IsChoice, name)
+	}
+	item, _ = Item("Iron Ball")
+	assert.Equal(t, 130, item.Fling)
+	assert.False(t, item.IsChoice)
+
+	item, _ = Item("Earth Plate")
+	assert.Equal(t, "Ground", item.OnPlate)
+	item, _ = Item("Metronome")
+	assert.Equal(t, 30, item.Fling)
+	item, ok = Item("Fire Gem")
+	assert.True(t, ok)
+	assert.Zero(t, item.Fling)
+
+	for _, name := range []string{"", "No Item", "Master Ball"} {
+		_, ok := Item(name)
+		assert.False(t, ok, name)
+	}
+}
+
+// TestItem_data checks the consistency of the item table.
+func TestItem_data(t *testing.T) {
+	t.Parallel()
+	entries := itemTable.load()
+	assert.NotEmpty(t, entries)
+	for id, item := range entries {
+		assert.Equal(t, id, ToID(item.Name))
+		assert.True(t, item.Fling >= 0 && item.Fling <= 130, item.Name)
+		for _, user := range item.ItemUser {
+			s, ok := Species(user)
+			assert.True(t, ok, item.Name)
+			assert.Equal(t, user, s.Name, item.Name)
+		}
+		if item.IsMegaStone() {
+			s, ok := Species(item.MegaStone)
+			assert.True(t, ok, item.Name)
+			assert.Equal(t, item.MegaEvolves, s.BaseSpecies, item.Name)
+			assert.Equal(t, item.Name, s.RequiredItem, item.Name)
+			assert.Zero(t, item.Fling, item.Name)
+		}
+		if item.ZCrystal {
+			assert.NotEqual(t, len(item.ZMove) > 0, len(item.ZMoveType) > 0, item.Name)
+			assert.Equal(t, len(item.ZMove) > 0, len(item.ZMoveFrom) > 0, item.Name)
+			if len(item.ZMoveFrom) > 0 {
+				_, err := Resolve(KindMove, item.ZMoveFrom)
+				assert.NoError(t, err, item.Name)
+			}
+			assert.Zero(t, item.Fling, item.Name)
+		}
+		if item.IsBerry {
+			assert.Equal(t, 10, item.Fling, item.Name)
+			assert.NotEmpty(t, item.NaturalGift.Type, item.Name)
+		} else {
+			assert.Zero(t, item.NaturalGift, item.Name)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package dex
+
+// MoveFlags are the properties of a move which interact with abilities and items,
+// e.g. Contact for Rocky Helmet, Sound for Soundproof and Punch for Iron Fist.
+type MoveFlags struct {
+	Contact  bool
+	Sound    bool
+	Punch    bool
+	Bite     bool
+	Bullet   bool
+	Pulse    bool
+	Slicing  bool
+	Wind     bool
+	Powder   bool
+	Heal     bool
+	Charge   bool
+	Recharge bool
+}
+
+// MoveData is the data of a move in the latest generation, e.g. Will-O-Wisp.
+type MoveData struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	// Category is "Physical", "Special" or "Status".
+	Category string `json:"category"`
+	// BasePower is 0 for status moves, and for moves whose power is computed in battle, e.g. Heavy Slam.
+	BasePower int `json:"basePower"`
+	// Accuracy is the chance to hit in percent, or 0 for moves which never miss, e.g. Aura Sphere.
+	Accuracy int `json:"accuracy"`
+	// PP is the number of uses without PP Ups.
+	PP       int `json:"pp"`
+	Priority int `json:"priority"`
+	// Target is the target of the move as named by Pokémon Showdown,
+	// e.g. "normal" for an adjacent Pokémon, "allAdjacentFoes" for a spread move or "self".
+	Target string    `json:"target"`
+	Flags  MoveFlags `json:"flags"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler for the layout of Pokémon Showdown,
+// in which the accuracy of moves that never miss is true and the flags are set to 1.
+func (m *MoveData) UnmarshalJSON(data []byte) error {
+	type move MoveData
+	var v struct {
+		move
+		Accuracy interface{}    `json:"accuracy"`
+		Flags    map[string]int `json:"flags"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*m = MoveData(v.move)
+	if accuracy, ok := v.Accuracy.(float64); ok {
+		m.Accuracy = int(accuracy)
+	}
+	for flag, set := range v.Flags {
+		if set == 0 {
+			continue
+		}
+		switch flag {
+		case "contact":
+			m.Flags.Contact = true
+		case "sound":
+			m.Flags.Sound = true
+		case "punch":
+			m.Flags.Punch = true
+		case "bite":
+			m.Flags.Bite = true
+		case "bullet":
+			m.Flags.Bullet = true
+		case "pulse":
+			m.Flags.Pulse = true
+		case "slicing":
+			m.Flags.Slicing = true
+		case "wind":
+			m.Flags.Wind = true
+		case "powder":
+			m.Flags.Powder = true
+		case "heal":
+			m.Flags.Heal = true
+		case "charge":
+			m.Flags.Charge = true
+		case "recharge":
+			m.Flags.Recharge = true
+		}
+	}
+	return nil
+}
+
+// NeverMisses reports whether the move skips the accuracy check.
+func (m MoveData) NeverMisses() bool {
+	return m.Accuracy == 0
+}
+
+// Damaging reports whether the move is physical or special.
+func (m MoveData) Damaging() bool {
+	return m.Category != "Status"
+}
+
+var moveTable = table[MoveData]{file: "data/moves.json", name: func(m *MoveData) string { return m.Name }}
+
+// Move returns the data of a move by its name or ID, e.g. "Will-O-Wisp" or "willowisp", or false if it is unknown.
+func Move(name string) (MoveData, bool) {
+	m := moveTable.get(name)
+	if m == nil {
+		return MoveData{}, false
+	}
+	return *m, true
+}
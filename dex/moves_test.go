@@ -0,0 +1,93 @@
+package dex
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleMove() {
+	for _, name := range []string{"Will-O-Wisp", "sludgebomb", "Aura Sphere"} {
+		m, _ := Move(name)
+		fmt.Println(m.Name, m.Type, m.Category, m.BasePower, m.Accuracy, m.PP, m.Flags.Bullet)
+	}
+	// Output:
+	// Will-O-Wisp Fire Status 0 85 15 false
+	// Sludge Bomb Poison Special 90 100 10 true
+	// Aura Sphere Fighting Special 80 0 20 true
+}
+
+func TestMove(t *testing.T) {
+	t.Parallel()
+	m, ok := Move("Drain Punch")
+	assert.True(t, ok)
+	assert.Equal(t, MoveData{
+		Name: "Drain Punch", Type: "Fighting", Category: "Physical", BasePower: 75, Accuracy: 100, PP: 10, Target: "normal",
+		Flags: MoveFlags{Contact: true, Punch: true, Heal: true},
+	}, m)
+	assert.True(t, m.Damaging())
+	assert.False(t, m.NeverMisses())
+
+	m, _ = Move("trick room")
+	assert.Equal(t, -7, m.Priority)
+	assert.Equal(t, "all", m.Target)
+	assert.False(t, m.Damaging())
+	assert.True(t, m.NeverMisses())
+
+	m, _ = Move("Fake Out")
+	assert.Equal(t, 3, m.Priority)
+	m, _ = Move("Hyper Voice")
+	assert.True(t, m.Flags.Sound)
+	assert.Equal(t, "allAdjacentFoes", m.Target)
+
+	m, _ = Move("Roar")
+	assert.Equal(t, -6, m.Priority)
+	assert.True(t, m.Flags.Sound)
+	assert.True(t, m.NeverMisses())
+	m, _ = Move("Leaf Blade")
+	assert.Equal(t, MoveFlags{Contact: true, Slicing: true}, m.Flags)
+	m, _ = Move("Heat Crash")
+	assert.Zero(t, m.BasePower)
+	assert.True(t, m.Damaging())
+	for _, name := range []string{"Tackle", "Bullet Seed", "Fake Tears"} {
+		_, ok := Move(name)
+		assert.True(t, ok, name)
+	}
+
+	for _, name := range []string{"", "Hidden Power [Fire]", "Splash"} {
+		_, ok := Move(name)
+		assert.False(t, ok, name)
+	}
+}
+
+// TestMove_data checks the consistency of the move table.
+func TestMove_data(t *testing.T) {
+	t.Parallel()
+	types := map[string]bool{}
+	for _, s := range speciesTable.load() {
+		for _, typ := range s.Types {
+			types[typ] = true
+		}
+	}
+	targets := []string{
+		"normal", "any", "self", "adjacentAlly", "adjacentAllyOrSelf", "adjacentFoe", "allAdjacent", "allAdjacentFoes",
+		"allies", "allySide", "foeSide", "all", "randomNormal", "scripted",
+	}
+	entries := moveTable.load()
+	assert.NotEmpty(t, entries)
+	for id, m := range entries {
+		assert.Equal(t, id, ToID(m.Name))
+		assert.True(t, types[m.Type], m.Name)
+		assert.Contains(t, []string{"Physical", "Special", "Status"}, m.Category, m.Name)
+		if !m.Damaging() {
+			assert.Zero(t, m.BasePower, m.Name)
+			assert.False(t, m.Flags.Contact, m.Name)
+		}
+		assert.True(t, m.Accuracy >= 0 && m.Accuracy <= 100, m.Name)
+		// Sketch and the Z-Moves have 1 PP
+		assert.True(t, m.PP == 1 || m.PP >= 5 && m.PP <= 40 && m.PP%5 == 0, m.Name)
+		assert.True(t, m.Priority >= -7 && m.Priority <= 5, m.Name)
+		assert.Contains(t, targets, m.Target, m.Name)
+	}
+}
@@ -0,0 +1,205 @@
+package dex
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Kind is a kind of names in the data, e.g. KindItem for "Leftovers".
+type Kind string
+
+const (
+	KindSpecies Kind = "species"
+	KindItem    Kind = "item"
+	KindAbility Kind = "ability"
+	KindMove    Kind = "move"
+	KindNature  Kind = "nature"
+)
+
+// Entry is a name of the data with its kind and ID.
+type Entry struct {
+	Kind Kind
+	// ID is the ID of the name, see ToID.
+	ID   string
+	Name string
+}
+
+// namedData is an entry of the tables which are only used to resolve names so far.
+type namedData struct {
+	Name string `json:"name"`
+}
+
+var natureTable = table[namedData]{file: "data/natures.json", name: func(d *namedData) string { return d.Name }}
+
+// nameTable is a data table whose names can be resolved.
+type nameTable interface {
+	nameOf(id string) (string, bool)
+	each(f func(id, name string))
+}
+
+// nameTables are the tables of each kind.
+var nameTables = map[Kind]nameTable{
+	KindSpecies: &speciesTable,
+	KindItem:    &itemTable,
+	KindAbility: &abilityTable,
+	KindMove:    &moveTable,
+	KindNature:  &natureTable,
+}
+
+var (
+	aliasesOnce sync.Once
+	// aliases are the common abbreviations of names keyed by their IDs, e.g. "Landorus-Therian" for "landot".
+	aliases map[string]string
+)
+
+func loadAliases() map[string]string {
+	aliasesOnce.Do(func() {
+		mustDecode("data/aliases.json", &aliases)
+	})
+	return aliases
+}
+
+// UnknownNameError is the error of Resolve for a name which is not in the data.
+type UnknownNameError struct {
+	Kind Kind
+	Name string
+	// Suggestions are the closest known names, best first, see Suggest.
+	Suggestions []Entry
+}
+
+// Error returns the unknown name followed by the suggestions, e.g. `unknown item: Lefovers (did you mean Leftovers?)`.
+func (e *UnknownNameError) Error() string {
+	msg := fmt.Sprintf("unknown %s: %s", e.Kind, e.Name)
+	if len(e.Suggestions) == 0 {
+		return msg
+	}
+	names := make([]string, len(e.Suggestions))
+	for i, s := range e.Suggestions {
+		names[i] = s.Name
+	}
+	return msg + " (did you mean " + strings.Join(names, " or ") + "?)"
+}
+
+// maxSuggestions is the number of suggestions of the errors of Resolve.
+const maxSuggestions = 3
+
+// Resolve returns the canonical entry of a name of the kind, comparing IDs, so that "venusaur gmax" gives "Venusaur-Gmax".
+// Common abbreviations are resolved as well, e.g. "Lando-T" gives "Landorus-Therian".
+// If the name is unknown, the error is an *UnknownNameError with up to 3 suggestions.
+func Resolve(kind Kind, name string) (Entry, error) {
+	t, ok := nameTables[kind]
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown kind: %s", kind)
+	}
+	id := ToID(name)
+	if canonical, ok := t.nameOf(id); ok {
+		return Entry{Kind: kind, ID: id, Name: canonical}, nil
+	}
+	if alias, ok := loadAliases()[id]; ok {
+		if canonical, ok := t.nameOf(ToID(alias)); ok {
+			return Entry{Kind: kind, ID: ToID(alias), Name: canonical}, nil
+		}
+	}
+	return Entry{}, &UnknownNameError{Kind: kind, Name: name, Suggestions: Suggest(kind, name, maxSuggestions)}
+}
+
+// Suggest returns up to max names of the kind whose IDs are the closest to the one of name, best first.
+// Like the "did you mean" of Pokémon Showdown, a name is close if its ID is at most 1 edit away for IDs of up to 4 letters,
+// 2 edits for IDs of up to 6 letters and 3 edits for longer ones, an edit being an insertion, a deletion, a substitution
+// or a transposition of adjacent letters. Ties are ordered by name. An exact match is its own suggestion.
+func Suggest(kind Kind, name string, max int) []Entry {
+	t, ok := nameTables[kind]
+	id := ToID(name)
+	if !ok || len(id) <= 1 || max <= 0 {
+		return nil
+	}
+	limit := 3
+	switch {
+	case len(id) <= 4:
+		limit = 1
+	case len(id) <= 6:
+		limit = 2
+	}
+	type candidate struct {
+		Entry
+		distance int
+	}
+	var candidates []candidate
+	t.each(func(entryID, entryName string) {
+		if d := editDistance(id, entryID, limit); d <= limit {
+			candidates = append(candidates, candidate{Entry{Kind: kind, ID: entryID, Name: entryName}, d})
+		}
+	})
+	sort.Slice(candidates, func(i, j int) bool {
+		if candidates[i].distance != candidates[j].distance {
+			return candidates[i].distance < candidates[j].distance
+		}
+		return candidates[i].Name < candidates[j].Name
+	})
+	if len(candidates) > max {
+		candidates = candidates[:max]
+	}
+	suggestions := make([]Entry, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = c.Entry
+	}
+	return suggestions
+}
+
+// editDistance returns the optimal string alignment distance between two IDs, or limit+1 if it exceeds limit.
+func editDistance(a, b string, limit int) int {
+	if abs(len(a)-len(b)) > limit {
+		return limit + 1
+	}
+	// three rows of the dynamic programming matrix, for the transpositions
+	prev2 := make([]int, len(b)+1)
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		rowMin := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d := minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d = minInt(d, prev2[j-2]+1)
+			}
+			cur[j] = d
+			if d < rowMin {
+				rowMin = d
+			}
+		}
+		if rowMin > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	if prev[len(b)] > limit {
+		return limit + 1
+	}
+	return prev[len(b)]
+}
+
+func minInt(x int, ys ...int) int {
+	for _, y := range ys {
+		if y < x {
+			x = y
+		}
+	}
+	return x
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,175 @@
+package dex
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleResolve() {
+	for _, name := range []string{"venusaur gmax", "Lando-T"} {
+		e, _ := Resolve(KindSpecies, name)
+		fmt.Println(e.ID, e.Name)
+	}
+	_, err := Resolve(KindItem, "Lefovers")
+	fmt.Println(err)
+	// Output:
+	// venusaurgmax Venusaur-Gmax
+	// landorustherian Landorus-Therian
+	// unknown item: Lefovers (did you mean Leftovers?)
+}
+
+func TestToID(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"Venusaur-Gmax", "venusaurgmax"},
+		{"venusaur gmax", "venusaurgmax"},
+		{"Mr. Mime", "mrmime"},
+		{"Farfetch’d", "farfetchd"},
+		{"Flabébé", "flabb"},
+		{"Porygon-Z", "porygonz"},
+		{"Will-O-Wisp", "willowisp"},
+		{"King's Rock", "kingsrock"},
+		{" 10,000,000 Volt Thunderbolt ", "10000000voltthunderbolt"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, ToID(tt.name), tt.name)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		kind Kind
+		name string
+		want string
+	}{
+		{KindSpecies, "VENUSAUR-GMAX", "Venusaur-Gmax"},
+		{KindSpecies, "ttar", "Tyranitar"},
+		{KindItem, "lefties", "Leftovers"},
+		{KindItem, "king's rock", "King's Rock"},
+		{KindAbility, "neutralizinggas", "Neutralizing Gas"},
+		{KindMove, "Will o Wisp", "Will-O-Wisp"},
+		{KindMove, "uturn", "U-turn"},
+		{KindNature, "BOLD", "Bold"},
+	}
+	for _, tt := range tests {
+		e, err := Resolve(tt.kind, tt.name)
+		assert.NoError(t, err, tt.name)
+		assert.Equal(t, Entry{Kind: tt.kind, ID: ToID(tt.want), Name: tt.want}, e, tt.name)
+	}
+
+	// aliases of another kind are not resolved
+	_, err := Resolve(KindMove, "lefties")
+	assert.Error(t, err)
+	_, err = Resolve(Kind("type"), "Fire")
+	assert.EqualError(t, err, "unknown kind: type")
+
+	_, err = Resolve(KindNature, "Bald")
+	var unknown *UnknownNameError
+	assert.True(t, errors.As(err, &unknown))
+	assert.Equal(t, KindNature, unknown.Kind)
+	assert.Equal(t, "Bald", unknown.Name)
+	assert.Equal(t, []Entry{{Kind: KindNature, ID: "bold", Name: "Bold"}}, unknown.Suggestions)
+	assert.EqualError(t, err, "unknown nature: Bald (did you mean Bold?)")
+
+	_, err = Resolve(KindSpecies, "Missingno")
+	assert.EqualError(t, err, "unknown species: Missingno")
+}
+
+func TestSuggest(t *testing.T) {
+	t.Parallel()
+	names := func(entries []Entry) []string {
+		var names []string
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		return names
+	}
+	tests := []struct {
+		kind Kind
+		name string
+		max  int
+		want []string
+	}{
+		{KindItem, "Lefovers", 3, []string{"Leftovers"}},
+		{KindItem, "Letfovers", 3, []string{"Leftovers"}},
+		{KindMove, "Thunderbolt", 3, []string{"Thunderbolt"}},
+		{KindMove, "Thunder Bolt", 3, []string{"Thunderbolt"}},
+		{KindMove, "Thundr", 3, []string{"Thunder"}},
+		{KindNature, "Clam", 3, []string{"Calm"}},
+		{KindNature, "Mald", 3, []string{"Mild"}},
+		{KindSpecies, "Weezng-Galr", 3, []string{"Weezing-Galar"}},
+		// ties are ordered by name
+		{KindSpecies, "Rotom-Wah", 3, []string{"Rotom-Wash", "Rotom", "Rotom-Heat"}},
+		{KindSpecies, "Rotom-Heet", 1, []string{"Rotom-Heat"}},
+		{KindSpecies, "Charizard-Mega", 2, []string{"Charizard-Mega-X", "Charizard-Mega-Y"}},
+		{KindSpecies, "Charizard-Mega", 1, []string{"Charizard-Mega-X"}},
+		// too far or too short
+		{KindItem, "Pizza", 3, nil},
+		{KindNature, "B", 3, nil},
+		{KindItem, "Lefovers", 0, nil},
+		{Kind("type"), "Fire", 3, nil},
+	}
+	for _, tt := range tests {
+		got := Suggest(tt.kind, tt.name, tt.max)
+		assert.Equal(t, tt.want, names(got), tt.name)
+		for _, e := range got {
+			assert.Equal(t, tt.kind, e.Kind)
+			assert.Equal(t, ToID(e.Name), e.ID)
+		}
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b  string
+		limit int
+		want  int
+	}{
+		{"", "", 3, 0},
+		{"leftovers", "leftovers", 3, 0},
+		{"lefovers", "leftovers", 3, 1},
+		{"letfovers", "leftovers", 3, 1},
+		{"kitten", "sitting", 3, 3},
+		{"kitten", "sitting", 2, 3},
+		{"ca", "abc", 3, 3},
+		{"abc", "", 3, 3},
+		{"abcd", "", 3, 4},
+		{"", "abc", 1, 2},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, editDistance(tt.a, tt.b, tt.limit), "%s %s", tt.a, tt.b)
+		assert.Equal(t, tt.want, editDistance(tt.b, tt.a, tt.limit), "%s %s", tt.b, tt.a)
+	}
+}
+
+// TestResolve_data checks the consistency of the tables of names.
+func TestResolve_data(t *testing.T) {
+	t.Parallel()
+	for kind, table := range nameTables {
+		count := 0
+		table.each(func(id, name string) {
+			count++
+			assert.Equal(t, id, ToID(name), kind)
+		})
+		assert.NotZero(t, count, kind)
+	}
+	assert.Len(t, natureTable.load(), 25)
+	for alias, name := range loadAliases() {
+		resolved := false
+		for _, table := range nameTables {
+			_, ok := table.nameOf(ToID(name))
+			resolved = resolved || ok
+		}
+		assert.True(t, resolved, alias)
+		assert.Equal(t, alias, ToID(alias))
+	}
+}
@@ -0,0 +1,172 @@
+package dex
+
+// Stats contains a value for each of the six stats, such as base stats.
+// In gen 1, Spa and Spd both hold the Special stat.
+type Stats struct {
+	Hp  int `json:"hp"`
+	Atk int `json:"atk"`
+	Def int `json:"def"`
+	Spa int `json:"spa"`
+	Spd int `json:"spd"`
+	Spe int `json:"spe"`
+}
+
+// Total returns the sum of the stats.
+func (s Stats) Total() int {
+	return s.Hp + s.Atk + s.Def + s.Spa + s.Spd + s.Spe
+}
+
+// Abilities are the abilities a species may have. Pokémon have no abilities in gens 1 and 2,
+// and no hidden abilities before gen 5.
+type Abilities struct {
+	Primary   string `json:"0"`
+	Secondary string `json:"1,omitempty"`
+	Hidden    string `json:"H,omitempty"`
+	// Special is an ability only obtained in a special way, e.g. Battle Bond of Greninja-Ash.
+	Special string `json:"S,omitempty"`
+}
+
+// List returns the abilities which are set, in the order of the fields.
+func (a Abilities) List() []string {
+	list := make([]string, 0, 4)
+	for _, ability := range [...]string{a.Primary, a.Secondary, a.Hidden, a.Special} {
+		if len(ability) > 0 {
+			list = append(list, ability)
+		}
+	}
+	return list
+}
+
+// Has reports whether the ability is one of the abilities, comparing their IDs.
+func (a Abilities) Has(ability string) bool {
+	id := ToID(ability)
+	for _, name := range a.List() {
+		if ToID(name) == id {
+			return true
+		}
+	}
+	return false
+}
+
+// GenderRatio is the chance for a Pokémon of a species to be male or female.
+type GenderRatio struct {
+	M float64 `json:"M"`
+	F float64 `json:"F"`
+}
+
+// SpeciesData is the Pokédex entry of a species or a forme, e.g. Koffing or Weezing-Galar.
+// The slices are shared between lookups and must not be modified.
+type SpeciesData struct {
+	// Num is the National Pokédex number, which formes share with their base species.
+	Num  int    `json:"num"`
+	Name string `json:"name"`
+	// BaseSpecies and Forme are set for a forme, e.g. "Weezing" and "Galar" for Weezing-Galar.
+	BaseSpecies string   `json:"baseSpecies,omitempty"`
+	Forme       string   `json:"forme,omitempty"`
+	Types       []string `json:"types"`
+	// Gender is "M" or "F" for a species of a single gender, and "N" for a genderless one, whose GenderRatio is zero.
+	Gender      string      `json:"gender,omitempty"`
+	GenderRatio GenderRatio `json:"genderRatio"`
+	BaseStats   Stats       `json:"baseStats"`
+	Abilities   Abilities   `json:"abilities"`
+	HeightM     float64     `json:"heightm"`
+	// WeightKg is the weight in kilograms, which is 0 for Gigantamax formes.
+	WeightKg float64 `json:"weightkg"`
+	// Prevo and Evos are the species this one evolves from and into.
+	Prevo string   `json:"prevo,omitempty"`
+	Evos  []string `json:"evos,omitempty"`
+	// OtherFormes are the formes of a base species, e.g. Venusaur-Mega and Venusaur-Gmax for Venusaur.
+	OtherFormes []string `json:"otherFormes,omitempty"`
+	// RequiredItem is the item a forme needs, e.g. the mega stone of a Mega Evolution.
+	RequiredItem string `json:"requiredItem,omitempty"`
+	// Gen is the generation which introduced the species or forme.
+	Gen Gen `json:"gen"`
+	// past holds the data that changed since older generations, see Gen.Species.
+	past []pastSpecies
+}
+
+// pastSpecies holds the data of a species that differ up to and including a generation from the ones of the newer generations.
+type pastSpecies struct {
+	Gen       Gen        `json:"gen"`
+	Types     []string   `json:"types"`
+	BaseStats *Stats     `json:"baseStats"`
+	Abilities *Abilities `json:"abilities"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler, decoding the unexported data of older generations as well.
+func (s *SpeciesData) UnmarshalJSON(data []byte) error {
+	type species SpeciesData
+	var v struct {
+		species
+		Past []pastSpecies `json:"past"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = SpeciesData(v.species)
+	s.past = v.Past
+	// like in Pokémon Showdown, the ratio is omitted for species of a single gender or half male and half female
+	switch s.Gender {
+	case "M":
+		s.GenderRatio = GenderRatio{M: 1}
+	case "F":
+		s.GenderRatio = GenderRatio{F: 1}
+	case "N":
+		s.GenderRatio = GenderRatio{}
+	default:
+		if s.GenderRatio == (GenderRatio{}) {
+			s.GenderRatio = GenderRatio{M: 0.5, F: 0.5}
+		}
+	}
+	return nil
+}
+
+// NFE reports whether the species is not fully evolved, e.g. for the item Eviolite.
+func (s SpeciesData) NFE() bool {
+	return len(s.Evos) > 0
+}
+
+// Genderless reports whether Pokémon of the species have no gender.
+func (s SpeciesData) Genderless() bool {
+	return s.Gender == "N"
+}
+
+var speciesTable = table[SpeciesData]{file: "data/species.json", name: func(s *SpeciesData) string { return s.Name }}
+
+// Species returns the data of a species or forme in the latest generation by its name or ID, e.g. "Mr. Mime" or "mrmime",
+// or false if it is unknown.
+func Species(name string) (SpeciesData, bool) {
+	return Latest.Species(name)
+}
+
+// Species returns the data of a species or forme in the generation, or false if it is unknown or did not exist then.
+// The types, base stats and abilities are the ones of the generation, e.g. gen 1 has the Special stat in Spa and Spd.
+func (g Gen) Species(name string) (SpeciesData, bool) {
+	s := speciesTable.get(name)
+	if s == nil || !g.valid() || g < s.Gen {
+		return SpeciesData{}, false
+	}
+	species := *s
+	// the past data are ordered from the newest generation, and the oldest one that applies wins
+	for _, past := range s.past {
+		if g > past.Gen {
+			break
+		}
+		if past.Types != nil {
+			species.Types = past.Types
+		}
+		if past.BaseStats != nil {
+			species.BaseStats = *past.BaseStats
+		}
+		if past.Abilities != nil {
+			species.Abilities = *past.Abilities
+		}
+	}
+	switch {
+	case g < 3:
+		species.Abilities = Abilities{}
+	case g < 5:
+		species.Abilities.Hidden = ""
+	}
+	return species, true
+}
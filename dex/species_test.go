@@ -0,0 +1,184 @@
+package dex
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleSpecies() {
+	koffing, _ := Species("Koffing")
+	fmt.Println(koffing.Name, koffing.Types, koffing.BaseStats, koffing.Abilities.List(), koffing.WeightKg, koffing.NFE())
+	koffing, _ = Gen(1).Species("koffing")
+	fmt.Println(koffing.Name, koffing.Types, koffing.BaseStats, koffing.Abilities.List())
+	// Output:
+	// Koffing [Poison] {40 65 95 60 45 35} [Levitate Neutralizing Gas Stench] 1 true
+	// Koffing [Poison] {40 65 95 60 60 35} []
+}
+
+func TestSpecies(t *testing.T) {
+	t.Parallel()
+	s, ok := Species("Mr. Mime-Galar")
+	assert.True(t, ok)
+	assert.Equal(t, "Mr. Mime-Galar", s.Name)
+	assert.Equal(t, "Mr. Mime", s.BaseSpecies)
+	assert.Equal(t, "Galar", s.Forme)
+	assert.Equal(t, []string{"Ice", "Psychic"}, s.Types)
+	assert.Equal(t, Gen(8), s.Gen)
+	for _, name := range []string{"venusaur-gmax", "Venusaur-Gmax", "Venusaur Gmax", "VENUSAURGMAX"} {
+		s, ok := Species(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, "Venusaur-Gmax", s.Name, name)
+	}
+	for _, name := range []string{"", "Missingno", "Koffing-Mega"} {
+		_, ok := Species(name)
+		assert.False(t, ok, name)
+	}
+
+	s, _ = Species("Snorlax")
+	assert.Equal(t, 540, s.BaseStats.Total())
+	assert.Equal(t, GenderRatio{M: 0.875, F: 0.125}, s.GenderRatio)
+	assert.False(t, s.Genderless())
+	assert.True(t, s.Abilities.Has("thick fat"))
+	assert.False(t, s.Abilities.Has("Levitate"))
+	s, _ = Species("Tauros")
+	assert.Equal(t, GenderRatio{M: 1}, s.GenderRatio)
+	s, _ = Species("Koffing")
+	assert.Equal(t, GenderRatio{M: 0.5, F: 0.5}, s.GenderRatio)
+	s, _ = Species("Zapdos")
+	assert.True(t, s.Genderless())
+	assert.False(t, s.NFE())
+	s, _ = Species("Charizard-Mega-Y")
+	assert.Equal(t, "Charizardite Y", s.RequiredItem)
+	assert.Equal(t, Abilities{Primary: "Drought"}, s.Abilities)
+}
+
+func TestGen_Species(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		gen       Gen
+		name      string
+		ok        bool
+		types     []string
+		baseStats Stats
+		abilities Abilities
+	}{
+		{1, "Snorlax", true, []string{"Normal"}, Stats{160, 110, 65, 65, 65, 30}, Abilities{}},
+		{2, "Snorlax", true, []string{"Normal"}, Stats{160, 110, 65, 65, 110, 30}, Abilities{}},
+		{4, "Snorlax", true, []string{"Normal"}, Stats{160, 110, 65, 65, 110, 30}, Abilities{"Immunity", "Thick Fat", "", ""}},
+		{9, "Snorlax", true, []string{"Normal"}, Stats{160, 110, 65, 65, 110, 30}, Abilities{"Immunity", "Thick Fat", "Gluttony", ""}},
+		{1, "Magnemite", true, []string{"Electric"}, Stats{25, 35, 70, 95, 95, 45}, Abilities{}},
+		{2, "Magnemite", true, []string{"Electric", "Steel"}, Stats{25, 35, 70, 95, 55, 45}, Abilities{}},
+		{1, "Pikachu", true, []string{"Electric"}, Stats{35, 55, 30, 50, 50, 90}, Abilities{}},
+		{5, "Pikachu", true, []string{"Electric"}, Stats{35, 55, 30, 50, 40, 90}, Abilities{"Static", "", "Lightning Rod", ""}},
+		{6, "Pikachu", true, []string{"Electric"}, Stats{35, 55, 40, 50, 50, 90}, Abilities{"Static", "", "Lightning Rod", ""}},
+		{3, "Mr. Mime", true, []string{"Psychic"}, Stats{40, 45, 65, 100, 120, 90}, Abilities{"Soundproof", "", "", ""}},
+		{5, "Mr. Mime", true, []string{"Psychic"}, Stats{40, 45, 65, 100, 120, 90}, Abilities{"Soundproof", "Filter", "Technician", ""}},
+		{6, "Mr. Mime", true, []string{"Psychic", "Fairy"}, Stats{40, 45, 65, 100, 120, 90}, Abilities{"Soundproof", "Filter", "Technician", ""}},
+		{6, "Gengar", true, []string{"Ghost", "Poison"}, Stats{60, 65, 60, 130, 75, 110}, Abilities{"Levitate", "", "", ""}},
+		{7, "Gengar", true, []string{"Ghost", "Poison"}, Stats{60, 65, 60, 130, 75, 110}, Abilities{"Cursed Body", "", "", ""}},
+		{7, "Weezing", true, []string{"Poison"}, Stats{65, 90, 120, 85, 70, 60}, Abilities{"Levitate", "", "Stench", ""}},
+		{4, "Rotom-Wash", true, []string{"Electric", "Ghost"}, Stats{50, 65, 107, 105, 107, 86}, Abilities{"Levitate", "", "", ""}},
+		{5, "Rotom-Wash", true, []string{"Electric", "Water"}, Stats{50, 65, 107, 105, 107, 86}, Abilities{"Levitate", "", "", ""}},
+		{1, "Raichu", true, []string{"Electric"}, Stats{60, 90, 55, 90, 90, 100}, Abilities{}},
+		{5, "Raichu", true, []string{"Electric"}, Stats{60, 90, 55, 90, 80, 100}, Abilities{"Static", "", "Lightning Rod", ""}},
+		{8, "Zacian-Crowned", true, []string{"Fairy", "Steel"}, Stats{92, 170, 115, 80, 115, 148}, Abilities{"Intrepid Sword", "", "", ""}},
+		{9, "Zacian-Crowned", true, []string{"Fairy", "Steel"}, Stats{92, 150, 115, 80, 115, 148}, Abilities{"Intrepid Sword", "", "", ""}},
+		{6, "Pelipper", true, []string{"Water", "Flying"}, Stats{60, 50, 100, 85, 70, 65}, Abilities{"Keen Eye", "", "Rain Dish", ""}},
+		{7, "Pelipper", true, []string{"Water", "Flying"}, Stats{60, 50, 100, 95, 70, 65}, Abilities{"Keen Eye", "Drizzle", "Rain Dish", ""}},
+		{6, "Torkoal", true, []string{"Fire"}, Stats{70, 85, 140, 85, 70, 20}, Abilities{"White Smoke", "", "Shell Armor", ""}},
+		{1, "Ninetales", true, []string{"Fire"}, Stats{73, 76, 75, 100, 100, 100}, Abilities{}},
+		{7, "Ninetales-Alola", true, []string{"Ice", "Fairy"}, Stats{73, 67, 75, 81, 100, 109}, Abilities{"Snow Cloak", "", "Snow Warning", ""}},
+		{6, "Ninetales-Alola", false, nil, Stats{}, Abilities{}},
+		{3, "Garchomp", false, nil, Stats{}, Abilities{}},
+		{7, "Weezing-Galar", false, nil, Stats{}, Abilities{}},
+		{0, "Koffing", false, nil, Stats{}, Abilities{}},
+		{10, "Koffing", false, nil, Stats{}, Abilities{}},
+	}
+	for _, tt := range tests {
+		s, ok := tt.gen.Species(tt.name)
+		assert.Equal(t, tt.ok, ok, "%d %s", tt.gen, tt.name)
+		assert.Equal(t, tt.types, s.Types, "%d %s", tt.gen, tt.name)
+		assert.Equal(t, tt.baseStats, s.BaseStats, "%d %s", tt.gen, tt.name)
+		assert.Equal(t, tt.abilities, s.Abilities, "%d %s", tt.gen, tt.name)
+	}
+	// the data of the latest generation are kept
+	s, _ := Species("Magnemite")
+	assert.Equal(t, []string{"Electric", "Steel"}, s.Types)
+}
+
+// TestSpecies_gens checks that the table holds species of every generation, e.g. the weather setters of gen 3.
+func TestSpecies_gens(t *testing.T) {
+	t.Parallel()
+	byGen := make(map[Gen]int)
+	for _, s := range speciesTable.load() {
+		byGen[s.Gen]++
+	}
+	for gen := Gen(1); gen <= Latest; gen++ {
+		assert.GreaterOrEqual(t, byGen[gen], 5, "gen %d", gen)
+	}
+	for _, name := range []string{"Pelipper", "Torkoal", "Rayquaza", "Rayquaza-Mega", "Dondozo", "Ninetales-Alola", "Vulpix-Alola"} {
+		_, ok := Species(name)
+		assert.True(t, ok, name)
+	}
+}
+
+// TestSpecies_data checks the consistency of the species table.
+func TestSpecies_data(t *testing.T) {
+	t.Parallel()
+	entries := speciesTable.load()
+	assert.NotEmpty(t, entries)
+	for id, s := range entries {
+		assert.Equal(t, id, ToID(s.Name))
+		assert.True(t, s.Num > 0 && s.Gen.valid(), s.Name)
+		assert.True(t, len(s.Types) == 1 || len(s.Types) == 2, s.Name)
+		assert.NotEmpty(t, s.Abilities.Primary, s.Name)
+		for _, ability := range s.Abilities.List() {
+			_, err := Resolve(KindAbility, ability)
+			assert.NoError(t, err, s.Name)
+		}
+		if len(s.RequiredItem) > 0 {
+			_, err := Resolve(KindItem, s.RequiredItem)
+			assert.NoError(t, err, s.Name)
+		}
+		assert.True(t, s.BaseStats.Total() > 0, s.Name)
+		assert.Contains(t, []string{"", "M", "F", "N"}, s.Gender, s.Name)
+		if s.Genderless() {
+			assert.Zero(t, s.GenderRatio, s.Name)
+		} else {
+			assert.Equal(t, 1.0, s.GenderRatio.M+s.GenderRatio.F, s.Name)
+		}
+		if len(s.BaseSpecies) > 0 {
+			assert.Equal(t, s.BaseSpecies+"-"+s.Forme, s.Name)
+			base, ok := Species(s.BaseSpecies)
+			assert.True(t, ok, s.Name)
+			assert.Equal(t, base.Num, s.Num, s.Name)
+			assert.Contains(t, base.OtherFormes, s.Name)
+			assert.GreaterOrEqual(t, s.Gen, base.Gen, s.Name)
+		}
+		for _, forme := range s.OtherFormes {
+			assert.True(t, strings.HasPrefix(forme, s.Name+"-"), forme)
+			_, ok := Species(forme)
+			assert.True(t, ok, forme)
+		}
+		if len(s.Prevo) > 0 {
+			prevo, ok := Species(s.Prevo)
+			assert.True(t, ok, s.Name)
+			assert.Equal(t, s.Prevo, prevo.Name, s.Name)
+			assert.Contains(t, prevo.Evos, s.Name)
+		}
+		for _, evo := range s.Evos {
+			evolution, ok := Species(evo)
+			assert.True(t, ok, evo)
+			assert.Equal(t, evo, evolution.Name, s.Name)
+		}
+		for i, past := range s.past {
+			assert.True(t, past.Gen >= s.Gen && past.Gen < Latest, s.Name)
+			if i > 0 {
+				assert.Less(t, past.Gen, s.past[i-1].Gen, s.Name)
+			}
+		}
+	}
+}
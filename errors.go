@@ -0,0 +1,91 @@
+package koffing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorCode classifies a ParseError.
+type ErrorCode int
+
+const (
+	// CodeTooFewLines means a Pokémon set has less than 3 lines.
+	CodeTooFewLines ErrorCode = iota + 1
+	// CodeInvalidName means the name line, i.e. the first line of a set, cannot be parsed.
+	CodeInvalidName
+	// CodeInvalidLevel means the value of a "Level:" line is not a number.
+	CodeInvalidLevel
+	// CodeInvalidHappiness means the value of a "Happiness:" line is not a number.
+	CodeInvalidHappiness
+	// CodeInvalidDynamaxLevel means the value of a "Dynamax Level:" line is not a number.
+	CodeInvalidDynamaxLevel
+	// CodeInvalidStats means an "EVs:" or "IVs:" line cannot be parsed.
+	CodeInvalidStats
+	// CodeUnexpectedHeader means a team header appears where a single team or Pokémon is expected.
+	CodeUnexpectedHeader
+	// CodeUnknownLine means a line of a set cannot be recognized. It is an error in strict mode only.
+	CodeUnknownLine
+	// CodeLimitExceeded means the input exceeds a limit of ParseOptions.
+	CodeLimitExceeded
+	// CodeUnknownName means a name is missing from the dex but close to a known one, e.g. "Lefovers".
+	// It is only reported with ParseOptions.Canonicalize, and is a warning even in strict mode, since the dex may miss real names.
+	CodeUnknownName
+	// CodeInvalidNature means the nature of a "Nature" line is unknown, e.g. "Bald Nature". It is an error in strict mode only.
+	CodeInvalidNature
+)
+
+var errorCodeNames = map[ErrorCode]string{
+	CodeTooFewLines:         "too few lines",
+	CodeInvalidName:         "invalid name",
+	CodeInvalidLevel:        "invalid level",
+	CodeInvalidHappiness:    "invalid happiness",
+	CodeInvalidDynamaxLevel: "invalid dynamax level",
+	CodeInvalidStats:        "invalid stats",
+	CodeUnexpectedHeader:    "unexpected header",
+	CodeUnknownLine:         "unknown line",
+	CodeLimitExceeded:       "limit exceeded",
+	CodeUnknownName:         "unknown name",
+	CodeInvalidNature:       "invalid nature",
+}
+
+// String returns a short description of the ErrorCode.
+func (c ErrorCode) String() string {
+	if name, ok := errorCodeNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorCode(%d)", int(c))
+}
+
+// ParseError describes where and why parsing Showdown text failed. Use errors.As to retrieve it from a returned error.
+type ParseError struct {
+	// TeamIndex is the 0-based index of the team in a TeamCollection, or 0 when parsing a single team or Pokémon.
+	TeamIndex int
+	// PokemonIndex is the 0-based index of the Pokémon in its team, or -1 if the error is not in a set, e.g. in a header.
+	PokemonIndex int
+	// Line is the 1-based line number in the input.
+	Line int
+	// Column is the 1-based byte offset of the offending text in the line.
+	Column int
+	// Source is the offending line without the line break.
+	Source string
+	// Code classifies the error.
+	Code ErrorCode
+	// Err is the underlying error.
+	Err error
+}
+
+// Error returns the position of the error followed by its description.
+func (e *ParseError) Error() string {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "team %d, ", e.TeamIndex)
+	if e.PokemonIndex >= 0 {
+		fmt.Fprintf(&msg, "pokemon %d, ", e.PokemonIndex)
+	}
+	fmt.Fprintf(&msg, "line %d, column %d: %v", e.Line, e.Column, e.Err)
+	return msg.String()
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
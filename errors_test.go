@@ -0,0 +1,94 @@
+package koffing
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseError() {
+	s := `=== [gen8] Example Team ===
+
+Koffing @ Eviolite
+Ability: Levitate
+Bold Nature
+- Haze
+
+Weezing @ Black Sludge
+Ability: Levitate
+EVs: 252 HP / 4 Def / x SpD
+Bold Nature
+- Haze`
+	err := new(Team).FromShowdown(s)
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		fmt.Printf("pokemon: %d, line: %d, column: %d, code: %s, source: %q\n", parseErr.PokemonIndex, parseErr.Line, parseErr.Column, parseErr.Code, parseErr.Source)
+	}
+	fmt.Println(err)
+	// Output: pokemon: 1, line: 10, column: 23, code: invalid stats, source: "EVs: 252 HP / 4 Def / x SpD"
+	// team 0, pokemon 1, line 10, column 23: error in parsing evs/ivs line: strconv.Atoi: parsing "x": invalid syntax
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		s    string
+		// parse as a collection instead of a team
+		collection bool
+		want       ParseError
+	}{
+		{
+			name: "invalid name",
+			s:    "=== [gen8] A ===\n\n  @ Sitrus Berry\n  Ability: Misty Surge\n  Calm Nature\n  - Moonblast\n",
+			want: ParseError{TeamIndex: 0, PokemonIndex: 0, Line: 3, Column: 3, Source: "  @ Sitrus Berry", Code: CodeInvalidName},
+		},
+		{
+			name: "too few lines",
+			s:    "Koffing\nAbility: Levitate\n\nWeezing\nAbility: Levitate\nBold Nature\n",
+			want: ParseError{TeamIndex: 0, PokemonIndex: 0, Line: 1, Column: 1, Source: "Koffing", Code: CodeTooFewLines},
+		},
+		{
+			name: "unexpected header",
+			s:    "=== [gen8] A ===\n\nKoffing\nAbility: Levitate\n- Haze\n=== [gen8] B ===\n",
+			want: ParseError{TeamIndex: 0, PokemonIndex: -1, Line: 6, Column: 1, Source: "=== [gen8] B ===", Code: CodeUnexpectedHeader},
+		},
+		{
+			name:       "team index",
+			s:          "=== [gen8] A ===\n\nKoffing\nAbility: Levitate\n- Haze\n\n=== [gen8] B ===\n\nKoffing\nAbility: Levitate\n\tIVs: 0 Atk / 1x SpA\n- Haze\n",
+			collection: true,
+			want:       ParseError{TeamIndex: 1, PokemonIndex: 0, Line: 11, Column: 15, Source: "\tIVs: 0 Atk / 1x SpA", Code: CodeInvalidStats},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			if tt.collection {
+				err = new(TeamCollection).FromShowdown(tt.s)
+			} else {
+				err = new(Team).FromShowdown(tt.s)
+			}
+			var parseErr *ParseError
+			assert.True(t, errors.As(err, &parseErr))
+			assert.Error(t, parseErr.Unwrap())
+			parseErr.Err = nil
+			assert.Equal(t, tt.want, *parseErr)
+		})
+	}
+
+	err := new(Pokemon).FromShowdown("\n\n  Koffing")
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, 3, parseErr.Line)
+	assert.Equal(t, 3, parseErr.Column)
+	assert.Equal(t, CodeTooFewLines, parseErr.Code)
+	assert.Equal(t, "team 0, pokemon 0, line 3, column 3: invalid pokemon input: expected at least 3 lines, got 1", err.Error())
+}
+
+func TestErrorCode_String(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "invalid name", CodeInvalidName.String())
+	assert.Equal(t, "ErrorCode(0)", ErrorCode(0).String())
+}
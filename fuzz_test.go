@@ -0,0 +1,168 @@
+package koffing
+
+import (
+	"testing"
+)
+
+// The fuzz targets below check that no entry point panics on arbitrary input,
+// and that whatever is parsed successfully can be exported again without panicking.
+// The targets of the other parsers live next to their tests, e.g. FuzzParseDocument, FuzzDecoder_Next,
+// FuzzScan and FuzzParseNature.
+// Run one with e.g. `go test -fuzz=FuzzTeam_FromShowdown`.
+
+var showdownSeeds = []string{
+	"",
+	" \n\t\n",
+	"=== [gen8] ===",
+	"=== [gen8] A ===\n\n===  ===",
+	"Koffing\nAbility: Levitate\n- Haze",
+	"Koffing (M) @ Eviolite\nAbility: Levitate\nEVs: 252Atk\nIVs: 0 Speed / x SpA\n- Haze",
+	"(F) @\n@\n()\n- ",
+	"Level: 999\nHappiness: -1\nDynamax Level: 99\nEVs: / / /",
+	typoSet,
+	backup,
+}
+
+func FuzzPokemon_FromShowdown(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s, false)
+	}
+	f.Fuzz(func(t *testing.T, s string, strict bool) {
+		p := &Pokemon{}
+		if _, err := p.FromShowdownWithOptions(s, ParseOptions{Strict: strict}); err != nil {
+			return
+		}
+		exportPokemon(*p)
+	})
+}
+
+func FuzzTeam_FromShowdown(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s, false)
+	}
+	f.Fuzz(func(t *testing.T, s string, strict bool) {
+		team := &Team{}
+		if _, err := team.FromShowdownWithOptions(s, ParseOptions{Strict: strict, MaxPokemon: 6}); err != nil {
+			return
+		}
+		exportTeam(*team)
+	})
+}
+
+func FuzzTeamCollection_FromShowdown(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		c := &TeamCollection{}
+		if err := c.FromShowdown(s); err != nil {
+			return
+		}
+		_ = c.Validate()
+		_, _ = c.ToShowdown()
+		_, _ = c.ToStorage()
+		_, _ = c.ToJson()
+	})
+}
+
+func FuzzPokemon_FromJson(f *testing.F) {
+	f.Add(`{"name":"Koffing","ability":"Levitate","nature":"Bold","ivs":{"hp":-1},"moves":["Hidden Power [Fire]"]}`)
+	f.Add(`{"name":1}`)
+	f.Add(`null`)
+	f.Add(``)
+	f.Fuzz(func(t *testing.T, s string) {
+		p := &Pokemon{}
+		if err := p.FromJson(s); err != nil {
+			return
+		}
+		exportPokemon(*p)
+	})
+}
+
+func FuzzTeam_FromJson(f *testing.F) {
+	f.Add(`{"name":"A","format":"gen3ou","pokemon":[{"name":"Koffing","ivs":{"atk":99},"moves":["Hidden Power"]}]}`)
+	f.Add(`{"pokemon":null}`)
+	f.Add(`[]`)
+	f.Fuzz(func(t *testing.T, s string) {
+		team := &Team{}
+		if err := team.FromJson(s); err != nil {
+			return
+		}
+		exportTeam(*team)
+	})
+}
+
+func FuzzPokemon_FromPacked(f *testing.F) {
+	f.Add("Koffing||eviolite|levitate|haze|Bold|252,,,,,|M|,0,,,,|S|5|,,,,,")
+	f.Add("||||||||||")
+	f.Add("|||||||||||255,,,,,,,")
+	f.Add("")
+	f.Fuzz(func(t *testing.T, s string) {
+		p := &Pokemon{}
+		if err := p.FromPacked(s); err != nil {
+			return
+		}
+		exportPokemon(*p)
+	})
+}
+
+func FuzzTeamCollection_FromStorage(f *testing.F) {
+	f.Add(storage)
+	f.Add("gen8]|")
+	f.Add("]/|\n\n|")
+	f.Fuzz(func(t *testing.T, s string) {
+		c := &TeamCollection{}
+		if err := c.FromStorage(s); err != nil {
+			return
+		}
+		_, _ = c.ToStorage()
+		_, _ = c.ToShowdown()
+		for _, team := range c.Teams {
+			exportTeam(team)
+		}
+	})
+}
+
+// exportPokemon calls the exporters and the helpers of p, discarding the results.
+func exportPokemon(p Pokemon) {
+	_ = p.Validate()
+	_, _ = p.ToShowdown()
+	_, _ = p.ToPacked()
+	_, _ = p.ToJson()
+	for gen := 0; gen <= 9; gen++ {
+		_, _ = p.HiddenPower(gen)
+		_ = p.validateHiddenPower(gen)
+	}
+	_, _ = p.SuggestHiddenPowerIVs("Fire", 2)
+	_, _ = p.SuggestHiddenPowerIVs("Fire", 7)
+}
+
+// exportTeam calls the exporters of team and its Pokémon, discarding the results.
+func exportTeam(team Team) {
+	_ = team.Validate()
+	_, _ = team.ToShowdown()
+	_, _ = team.ToPacked()
+	_, _ = team.ToJson()
+	for _, p := range team.Pokemon {
+		exportPokemon(p)
+	}
+}
+
+func FuzzPokemon_FromShowdown_options(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s, false, true, 0, uint8(0))
+	}
+	f.Add(sloppySet, true, true, 0, uint8(2))
+	f.Add(chineseSet, false, true, 9, uint8(1))
+	f.Add("Chansey\nEVs: 252 Spc\n- Seismic Toss", true, false, 1, uint8(4))
+	f.Fuzz(func(t *testing.T, s string, strict, canonicalize bool, gen int, lang uint8) {
+		p := &Pokemon{}
+		if _, err := p.FromShowdownWithOptions(s, ParseOptions{Strict: strict, Canonicalize: canonicalize, Gen: gen}); err != nil {
+			return
+		}
+		exportPokemon(*p)
+		to := languages[int(lang)%len(languages)]
+		_, _ = p.ToShowdownWithOptions(ShowdownOptions{Language: to, Gen: gen, Spc: true})
+		_, _, _ = Translate(Team{Pokemon: []Pokemon{*p}}, "", to)
+	})
+}
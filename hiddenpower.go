@@ -0,0 +1,207 @@
+package koffing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// hiddenPowerTypes lists the types Hidden Power can take, indexed by the result of the type formula.
+var hiddenPowerTypes = [16]string{
+	"Fighting", "Flying", "Poison", "Ground", "Rock", "Bug", "Ghost", "Steel",
+	"Fire", "Water", "Grass", "Electric", "Psychic", "Ice", "Dragon", "Dark",
+}
+
+// HiddenPower is the type and base power of the move Hidden Power.
+type HiddenPower struct {
+	Type  string `json:"type"`
+	Power int    `json:"power"`
+}
+
+// ParseHiddenPower reports whether the move is Hidden Power, and returns its type if the move has one,
+// e.g. "Hidden Power [Fire]" and "Hidden Power Fire" both give "Fire", while "Hidden Power" gives an empty type.
+// Unknown types are returned as is, with the case normalized if they are known.
+func ParseHiddenPower(move string) (string, bool) {
+	const prefix = "hidden power"
+	move = strings.TrimSpace(move)
+	if len(move) < len(prefix) || !strings.EqualFold(move[:len(prefix)], prefix) {
+		return "", false
+	}
+	rest := move[len(prefix):]
+	if len(rest) > 0 && rest[0] != ' ' && rest[0] != '[' {
+		return "", false // e.g. "Hidden Powers"
+	}
+	typ := strings.TrimSpace(rest)
+	if strings.HasPrefix(typ, "[") && strings.HasSuffix(typ, "]") {
+		typ = strings.TrimSpace(typ[1 : len(typ)-1])
+	}
+	if known, ok := hiddenPowerType(typ); ok {
+		typ = known
+	}
+	return typ, true
+}
+
+// hiddenPowerType returns the canonical name of a Hidden Power type regardless of case.
+func hiddenPowerType(typ string) (string, bool) {
+	for _, t := range hiddenPowerTypes {
+		if strings.EqualFold(t, typ) {
+			return t, true
+		}
+	}
+	return "", false
+}
+
+// HiddenPowerMove returns the type of the Hidden Power among the receiver's moves.
+// The type is empty if the move has no type, and ok is false if the receiver does not know Hidden Power.
+func (p Pokemon) HiddenPowerMove() (typ string, ok bool) {
+	for _, move := range p.Moves {
+		if typ, ok := ParseHiddenPower(move); ok {
+			return typ, true
+		}
+	}
+	return "", false
+}
+
+// HiddenPower computes the type and base power of the receiver's Hidden Power from its IVs in generation gen.
+// Gen 2 uses DVs, which are taken as half of the IVs just like Showdown does.
+// The base power is always 60 since Gen 6.
+func (p Pokemon) HiddenPower(gen int) (HiddenPower, error) {
+	if gen < 2 {
+		return HiddenPower{}, fmt.Errorf("hidden power does not exist in gen %d", gen)
+	}
+	if err := checkIVs(p.Ivs); err != nil {
+		return HiddenPower{}, err
+	}
+	return hiddenPowerOf(p.Ivs, gen), nil
+}
+
+// checkIVs returns an error if any IV is out of range [0, 31].
+func checkIVs(ivs Stats) error {
+	for _, iv := range [6]int{ivs.Hp, ivs.Atk, ivs.Def, ivs.Spa, ivs.Spd, ivs.Spe} {
+		if iv < 0 || iv > 31 {
+			return fmt.Errorf("iv should be in range [0, 31], yours: %d", iv)
+		}
+	}
+	return nil
+}
+
+func hiddenPowerOf(ivs Stats, gen int) HiddenPower {
+	if gen == 2 {
+		atk, def, spe, spc := ivs.Atk/2, ivs.Def/2, ivs.Spe/2, ivs.Spa/2
+		return HiddenPower{
+			Type:  hiddenPowerTypes[4*(atk%4)+def%4],
+			Power: (5*(spc>>3+2*(spe>>3)+4*(def>>3)+8*(atk>>3))+spc%4)/2 + 31,
+		}
+	}
+	typeX, powerX := 0, 0
+	for i, iv := range [6]int{ivs.Hp, ivs.Atk, ivs.Def, ivs.Spe, ivs.Spa, ivs.Spd} {
+		typeX += (iv % 2) << i
+		powerX += (iv / 2 % 2) << i
+	}
+	power := 60
+	if gen < 6 {
+		power = powerX*40/63 + 30
+	}
+	return HiddenPower{Type: hiddenPowerTypes[typeX*15/63], Power: power}
+}
+
+// SuggestHiddenPowerIVs returns the IV spread nearest to the receiver's IVs, which gives Hidden Power the type typ in generation gen.
+// The distance is the sum of the absolute IV differences. Ties are broken in favor of the higher base power.
+// In gen 2, the HP IV is changed as well to agree with the HP DV, which is derived from the other DVs.
+func (p Pokemon) SuggestHiddenPowerIVs(typ string, gen int) (Stats, error) {
+	if gen < 2 {
+		return Stats{}, fmt.Errorf("hidden power does not exist in gen %d", gen)
+	}
+	known, ok := hiddenPowerType(typ)
+	if !ok {
+		return Stats{}, fmt.Errorf("invalid hidden power type: %s", typ)
+	}
+	typ = known
+	best, bestCost, bestPower := p.Ivs, -1, 0
+	try := func(ivs Stats) {
+		hp := hiddenPowerOf(ivs, gen)
+		if hp.Type != typ {
+			return
+		}
+		cost := abs(ivs.Hp-p.Ivs.Hp) + abs(ivs.Atk-p.Ivs.Atk) + abs(ivs.Def-p.Ivs.Def) +
+			abs(ivs.Spa-p.Ivs.Spa) + abs(ivs.Spd-p.Ivs.Spd) + abs(ivs.Spe-p.Ivs.Spe)
+		if bestCost < 0 || cost < bestCost || cost == bestCost && hp.Power > bestPower {
+			best, bestCost, bestPower = ivs, cost, hp.Power
+		}
+	}
+	if gen == 2 {
+		// only the Atk and Def DVs decide the type, and the HP DV follows from the others
+		for atk := 0; atk < 16; atk++ {
+			for def := 0; def < 16; def++ {
+				ivs := p.Ivs
+				ivs.Atk, ivs.Def = nearestDVIV(p.Ivs.Atk, atk), nearestDVIV(p.Ivs.Def, def)
+				ivs.Hp = nearestDVIV(p.Ivs.Hp, Pokemon{Ivs: ivs}.DVs().Hp)
+				try(ivs)
+			}
+		}
+		return best, nil
+	}
+	// the two lowest bits of each IV decide the type and base power
+	for bits := 0; bits < 1<<12; bits++ {
+		try(Stats{
+			Hp:  nearestIV(p.Ivs.Hp, bits&3, 4),
+			Atk: nearestIV(p.Ivs.Atk, bits>>2&3, 4),
+			Def: nearestIV(p.Ivs.Def, bits>>4&3, 4),
+			Spa: nearestIV(p.Ivs.Spa, bits>>6&3, 4),
+			Spd: nearestIV(p.Ivs.Spd, bits>>8&3, 4),
+			Spe: nearestIV(p.Ivs.Spe, bits>>10&3, 4),
+		})
+	}
+	return best, nil
+}
+
+// nearestIV returns the IV in [0, 31] nearest to iv that is congruent to r modulo m, preferring the higher one on ties.
+func nearestIV(iv, r, m int) int {
+	best := r
+	for v := r + m; v <= 31; v += m {
+		if abs(v-iv) <= abs(best-iv) {
+			best = v
+		}
+	}
+	return best
+}
+
+// nearestDVIV returns the IV nearest to iv whose half is dv, i.e. either 2*dv or 2*dv+1.
+func nearestDVIV(iv, dv int) int {
+	if iv > 2*dv {
+		return 2*dv + 1
+	}
+	return 2 * dv
+}
+
+// validateHiddenPower verifies that the type of the Hidden Power move agrees with the receiver's IVs in generation gen.
+// It matters in gens 2 to 6, and in gen 7 below level 100 where IVs cannot be Hyper Trained.
+// Since gen 7, the type can also be set by the "Hidden Power:" line, which has to agree with the move.
+func (p Pokemon) validateHiddenPower(gen int) error {
+	typ, ok := p.HiddenPowerMove()
+	if !ok || len(typ) == 0 || gen < 2 {
+		return nil
+	}
+	if gen >= 7 && len(p.HiddenPowerType) > 0 {
+		if !strings.EqualFold(typ, p.HiddenPowerType) {
+			return fmt.Errorf("hidden power type %s disagrees with the hidden power line %s", typ, p.HiddenPowerType)
+		}
+		return nil
+	}
+	if gen > 7 || gen == 7 && (p.Level == 0 || p.Level == 100) {
+		return nil
+	}
+	if err := checkIVs(p.Ivs); err != nil {
+		return err
+	}
+	if hp := hiddenPowerOf(p.Ivs, gen); hp.Type != typ {
+		return fmt.Errorf("hidden power type %s disagrees with the IVs, which give %s", typ, hp.Type)
+	}
+	return nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,159 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleParseHiddenPower() {
+	typ, ok := ParseHiddenPower("Hidden Power [fire]")
+	fmt.Println(typ, ok)
+	// Output: Fire true
+}
+
+func TestParseHiddenPower(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		move   string
+		want   string
+		wantOk bool
+	}{
+		{move: "Hidden Power [Fire]", want: "Fire", wantOk: true},
+		{move: "Hidden Power Ice", want: "Ice", wantOk: true},
+		{move: "hidden power [ grass ]", want: "Grass", wantOk: true},
+		{move: "Hidden Power", want: "", wantOk: true},
+		{move: "Hidden Power [Fairy]", want: "Fairy", wantOk: true},
+		{move: "Hidden Powers", want: "", wantOk: false},
+		{move: "Power Gem", want: "", wantOk: false},
+	}
+	for _, tt := range tests {
+		typ, ok := ParseHiddenPower(tt.move)
+		assert.Equal(t, tt.want, typ, tt.move)
+		assert.Equal(t, tt.wantOk, ok, tt.move)
+	}
+}
+
+func ExamplePokemon_HiddenPower() {
+	p := Pokemon{Ivs: Stats{Hp: 31, Atk: 30, Def: 31, Spa: 30, Spd: 31, Spe: 30}}
+	hp, _ := p.HiddenPower(5)
+	fmt.Printf("%+v", hp)
+	// Output: {Type:Fire Power:70}
+}
+
+func TestPokemon_HiddenPower(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		ivs  Stats
+		gen  int
+		want HiddenPower
+	}{
+		{name: "gen 3 max IVs", ivs: Stats{31, 31, 31, 31, 31, 31}, gen: 3, want: HiddenPower{Type: "Dark", Power: 70}},
+		{name: "gen 4 ice", ivs: Stats{31, 30, 30, 31, 31, 31}, gen: 4, want: HiddenPower{Type: "Ice", Power: 70}},
+		{name: "gen 5 min IVs", ivs: Stats{}, gen: 5, want: HiddenPower{Type: "Fighting", Power: 30}},
+		{name: "gen 6 fire", ivs: Stats{31, 30, 31, 30, 31, 30}, gen: 6, want: HiddenPower{Type: "Fire", Power: 60}},
+		{name: "gen 2 max DVs", ivs: Stats{31, 31, 31, 31, 31, 31}, gen: 2, want: HiddenPower{Type: "Dark", Power: 70}},
+		{name: "gen 2 min DVs", ivs: Stats{}, gen: 2, want: HiddenPower{Type: "Fighting", Power: 31}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hp, err := Pokemon{Ivs: tt.ivs}.HiddenPower(tt.gen)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, hp)
+		})
+	}
+	_, err := Pokemon{}.HiddenPower(1)
+	assert.Error(t, err)
+}
+
+func ExamplePokemon_SuggestHiddenPowerIVs() {
+	p := Pokemon{Ivs: Stats{Hp: 31, Atk: 0, Def: 31, Spa: 31, Spd: 31, Spe: 31}}
+	ivs, _ := p.SuggestHiddenPowerIVs("Ice", 7)
+	fmt.Printf("%+v", ivs)
+	// Output: {Hp:31 Atk:0 Def:30 Spa:31 Spd:31 Spe:31}
+}
+
+func TestPokemon_SuggestHiddenPowerIVs(t *testing.T) {
+	t.Parallel()
+	for _, gen := range []int{2, 3, 5, 6, 7} {
+		for _, typ := range hiddenPowerTypes {
+			p := Pokemon{Ivs: Stats{31, 31, 31, 31, 31, 31}}
+			ivs, err := p.SuggestHiddenPowerIVs(typ, gen)
+			assert.NoError(t, err)
+			hp := hiddenPowerOf(ivs, gen)
+			assert.Equal(t, typ, hp.Type, "gen %d", gen)
+			if gen >= 3 && gen < 6 {
+				// IVs of 30 keep the second lowest bit, thus the best base power
+				assert.Equal(t, 70, hp.Power, "gen %d %s", gen, typ)
+			}
+		}
+	}
+	// the current IVs are kept if they already give the type
+	p := Pokemon{Ivs: Stats{31, 30, 31, 30, 31, 30}}
+	ivs, err := p.SuggestHiddenPowerIVs("fire", 4)
+	assert.NoError(t, err)
+	assert.Equal(t, p.Ivs, ivs)
+
+	// in gen 2, the HP DV follows the changed Atk and Def DVs
+	p = Pokemon{Ivs: Stats{31, 31, 31, 31, 31, 31}}
+	ivs, err = p.SuggestHiddenPowerIVs("Ice", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{Hp: 31, Atk: 31, Def: 27, Spa: 31, Spd: 31, Spe: 31}, ivs)
+	ivs, err = p.SuggestHiddenPowerIVs("Dragon", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{Hp: 23, Atk: 31, Def: 29, Spa: 31, Spd: 31, Spe: 31}, ivs)
+	p.Ivs = ivs
+	assert.Equal(t, p.Ivs.Hp/2, p.DVs().Hp)
+
+	_, err = p.SuggestHiddenPowerIVs("Fairy", 4)
+	assert.Error(t, err)
+	_, err = p.SuggestHiddenPowerIVs("Fire", 1)
+	assert.Error(t, err)
+}
+
+func TestPokemon_validateHiddenPower(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{Ivs: Stats{31, 31, 31, 31, 31, 31}, Moves: []string{"Hidden Power [Fire]"}}
+	assert.Error(t, p.validateHiddenPower(3))
+	assert.Error(t, p.validateHiddenPower(6))
+	assert.NoError(t, p.validateHiddenPower(1))
+	assert.NoError(t, p.validateHiddenPower(0))
+	// Hyper Training at level 100
+	assert.NoError(t, p.validateHiddenPower(7))
+	p.Level = 50
+	assert.Error(t, p.validateHiddenPower(7))
+	p.HiddenPowerType = "Fire"
+	assert.NoError(t, p.validateHiddenPower(7))
+	p.HiddenPowerType = "Ice"
+	assert.Error(t, p.validateHiddenPower(8))
+
+	p = Pokemon{Ivs: Stats{31, 30, 31, 30, 31, 30}, Moves: []string{"Hidden Power Fire"}}
+	assert.NoError(t, p.validateHiddenPower(5))
+	p.Moves = []string{"Hidden Power"}
+	assert.NoError(t, p.validateHiddenPower(5))
+}
+
+func TestTeam_Validate_hiddenPower(t *testing.T) {
+	t.Parallel()
+	team := Team{
+		Format:  "gen5ou",
+		Pokemon: []Pokemon{{Name: "Magnezone", Ability: "Magnet Pull", Nature: "Modest", Happiness: 255, Ivs: Stats{31, 31, 31, 31, 31, 31}, Moves: []string{"Hidden Power [Fire]"}}},
+	}
+	assert.Error(t, team.Validate())
+	team.Pokemon[0].Ivs = Stats{31, 30, 31, 30, 31, 30}
+	assert.NoError(t, team.Validate())
+
+	s, err := team.Pokemon[0].ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "- Hidden Power [Fire]  \n")
+}
+
+func Test_formatGen(t *testing.T) {
+	t.Parallel()
+	tests := map[string]int{"gen8vgc2021": 8, "gen1ou": 1, "gen10ou": 10, "ou": 0, "": 0, "gen": 0}
+	for format, want := range tests {
+		assert.Equal(t, want, formatGen(format), format)
+	}
+}
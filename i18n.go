@@ -0,0 +1,322 @@
+package koffing
+
+import (
+	"embed"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// Language is a language of the names and labels of Showdown text, identified by its ISO 639-1 code.
+type Language string
+
+const (
+	English  Language = "en"
+	Japanese Language = "ja"
+	Chinese  Language = "zh" // Simplified Chinese
+	Korean   Language = "ko"
+	French   Language = "fr"
+	German   Language = "de"
+	Spanish  Language = "es"
+)
+
+// languages lists the supported languages, English first.
+var languages = [...]Language{English, Japanese, Chinese, Korean, French, German, Spanish}
+
+// valid reports whether the language is supported.
+func (l Language) valid() bool {
+	for _, lang := range languages {
+		if l == lang {
+			return true
+		}
+	}
+	return false
+}
+
+// The categories of the names in the translation tables.
+const (
+	speciesNames = "species"
+	moveNames    = "moves"
+	itemNames    = "items"
+	abilityNames = "abilities"
+	natureNames  = "natures"
+	typeNames    = "types"
+)
+
+// i18nFiles holds the translation tables: labels.json maps each language to the labels of Showdown text keyed by their English label,
+// and names.json lists the translations of each name by category. go generate rebuilds names.json from the data of PokeAPI,
+// see internal/i18ngen. Names missing from the tables are kept as is and reported by Translate.
+//
+//go:embed i18n/*.json
+var i18nFiles embed.FS
+
+//go:generate go run ./internal/i18ngen
+
+// translations are the translation tables indexed for lookups.
+type translations struct {
+	// labels maps a language other than English to its labels keyed by their English label
+	labels map[Language]map[string]string
+	// names maps a category and a language to the translations of the lower-cased names in that language
+	names map[string]map[Language]map[string]map[Language]string
+	// localLabels are the labels other than English, longest first so that "Niveau Dynamax" is tried before "Niveau"
+	localLabels []localLabel
+	// stats maps the lower-cased localized stat names to the labels used in EVs/IVs lines
+	stats map[string]string
+	// yes holds the lower-cased localized words for "Yes"
+	yes map[string]bool
+}
+
+// localLabel is a label of Showdown text in a language other than English.
+type localLabel struct {
+	text, english string
+}
+
+var (
+	i18nOnce sync.Once
+	i18n     *translations
+)
+
+// loadTranslations returns the translation tables, which are decoded at the first call.
+func loadTranslations() *translations {
+	i18nOnce.Do(func() {
+		i18n = &translations{
+			labels: make(map[Language]map[string]string),
+			names:  make(map[string]map[Language]map[string]map[Language]string),
+			stats:  make(map[string]string),
+			yes:    make(map[string]bool),
+		}
+		var labels map[Language]map[string]string
+		if err := decodeI18nFile("i18n/labels.json", &labels); err != nil {
+			panic(err)
+		}
+		stats := make(map[string]bool, len(statShortNames))
+		for _, stat := range statShortNames {
+			stats[stat] = true
+		}
+		for lang, table := range labels {
+			if lang == English {
+				continue
+			}
+			i18n.labels[lang] = table
+			for english, text := range table {
+				switch {
+				case english == "Yes":
+					i18n.yes[strings.ToLower(text)] = true
+				case stats[english]:
+					i18n.stats[strings.ToLower(text)] = english
+				case text != english || english == "Nature":
+					i18n.localLabels = append(i18n.localLabels, localLabel{text: text, english: english})
+				}
+			}
+		}
+		sort.Slice(i18n.localLabels, func(i, j int) bool {
+			a, b := i18n.localLabels[i], i18n.localLabels[j]
+			if len(a.text) != len(b.text) {
+				return len(a.text) > len(b.text)
+			}
+			return a.text < b.text
+		})
+		var names map[string][]map[Language]string
+		if err := decodeI18nFile("i18n/names.json", &names); err != nil {
+			panic(err)
+		}
+		for category, entries := range names {
+			index := make(map[Language]map[string]map[Language]string)
+			for _, lang := range languages {
+				index[lang] = make(map[string]map[Language]string, len(entries))
+			}
+			for _, entry := range entries {
+				for lang, name := range entry {
+					index[lang][strings.ToLower(name)] = entry
+				}
+			}
+			i18n.names[category] = index
+		}
+	})
+	return i18n
+}
+
+func decodeI18nFile(name string, v interface{}) error {
+	b, err := i18nFiles.ReadFile(name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		return fmt.Errorf("invalid translation table %s: %w", name, err)
+	}
+	return nil
+}
+
+// translateName translates a name of the category from one language to another, and reports whether the name is in the tables.
+// An empty from means any language, English first. Unknown names are returned as is.
+func translateName(category, name string, from, to Language) (string, bool) {
+	if len(name) == 0 {
+		return name, true
+	}
+	index := loadTranslations().names[category]
+	// ASCII names are lower-cased in a buffer, so that the lookups do not allocate
+	var buf [32]byte
+	key := lowerKey(buf[:0], strings.TrimSpace(name))
+	if len(from) > 0 {
+		if entry, ok := index[from][string(key)]; ok {
+			return entry[to], true
+		}
+		return name, false
+	}
+	for _, lang := range languages {
+		if entry, ok := index[lang][string(key)]; ok {
+			if lang == to {
+				return name, true // keep the original case
+			}
+			return entry[to], true
+		}
+	}
+	return name, false
+}
+
+// lowerKey appends the lower-cased name to buf.
+func lowerKey(buf []byte, name string) []byte {
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c >= utf8.RuneSelf {
+			return append(buf[:0], strings.ToLower(name)...)
+		}
+		if c >= 'A' && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		buf = append(buf, c)
+	}
+	return buf
+}
+
+// translateMove translates a move, including the type of moves like "Hidden Power [Fire]", and reports whether it is in the tables.
+func translateMove(move string, from, to Language) (string, bool) {
+	if to != English && (from == English || len(from) == 0) {
+		if typ, ok := ParseHiddenPower(move); ok && len(typ) > 0 {
+			move = "Hidden Power [" + typ + "]"
+		}
+	}
+	if i := strings.LastIndexByte(move, '['); i > 0 && strings.HasSuffix(move, "]") {
+		name := strings.TrimSpace(move[:i])
+		translated, ok := translateName(moveNames, name, from, to)
+		typ, typeOK := translateName(typeNames, move[i+1:len(move)-1], from, to)
+		return translated + " [" + typ + "]", ok && typeOK
+	}
+	return translateName(moveNames, move, from, to)
+}
+
+// UntranslatedName is a name which Translate kept as is, since it is missing from the translation tables.
+type UntranslatedName struct {
+	// PokemonIndex is the 0-based index of the Pokémon in the team.
+	PokemonIndex int
+	// Field is the field of Pokemon holding the name, e.g. "Item" or "Moves".
+	Field string
+	Name  string
+}
+
+// translateNames translates the names of the receiver in place, and returns the untranslated ones. Nicknames are kept.
+func (p *Pokemon) translateNames(from, to Language) []UntranslatedName {
+	var untranslated []UntranslatedName
+	translate := func(field, category string, name *string) {
+		var ok bool
+		if *name, ok = translateName(category, *name, from, to); !ok {
+			untranslated = append(untranslated, UntranslatedName{Field: field, Name: *name})
+		}
+	}
+	translate("Name", speciesNames, &p.Name)
+	translate("Item", itemNames, &p.Item)
+	translate("Ability", abilityNames, &p.Ability)
+	translate("Nature", natureNames, &p.Nature)
+	translate("TeraType", typeNames, &p.TeraType)
+	translate("HiddenPowerType", typeNames, &p.HiddenPowerType)
+	// the moves are copied on the first change, since they may be shared with another Pokémon
+	copied := false
+	for i, move := range p.Moves {
+		translated, ok := translateMove(move, from, to)
+		if !ok {
+			untranslated = append(untranslated, UntranslatedName{Field: "Moves", Name: move})
+		}
+		if translated != move {
+			if !copied {
+				p.Moves, copied = append([]string(nil), p.Moves...), true
+			}
+			p.Moves[i] = translated
+		}
+	}
+	return untranslated
+}
+
+// Translate returns a copy of the team whose species, item, ability, nature, type and move names are translated
+// from one language to another. Names missing from the translation tables are kept as is and returned as untranslated,
+// in the order of the Pokémon and their fields, unless both languages are the same. Nicknames are kept as is.
+func Translate(t Team, from, to Language) (Team, []UntranslatedName, error) {
+	if !from.valid() {
+		return Team{}, nil, fmt.Errorf("unsupported language: %s", from)
+	}
+	if !to.valid() {
+		return Team{}, nil, fmt.Errorf("unsupported language: %s", to)
+	}
+	var untranslated []UntranslatedName
+	pokemon := make([]Pokemon, len(t.Pokemon))
+	for i, p := range t.Pokemon {
+		for _, name := range p.translateNames(from, to) {
+			if from != to {
+				name.PokemonIndex = i
+				untranslated = append(untranslated, name)
+			}
+		}
+		pokemon[i] = p
+	}
+	t.Pokemon = pokemon
+	return t, untranslated, nil
+}
+
+// showdownLabels returns the labels of Showdown text in the language keyed by their English label, or nil for English.
+func showdownLabels(lang Language) (map[string]string, error) {
+	if !lang.valid() {
+		return nil, fmt.Errorf("unsupported language: %s", lang)
+	}
+	return loadTranslations().labels[lang], nil
+}
+
+// localizedStatLabel returns the label of a stat name in a language other than English, e.g. "攻击" gives "Atk".
+func localizedStatLabel(name string) (string, bool) {
+	label, ok := loadTranslations().stats[strings.ToLower(name)]
+	return label, ok
+}
+
+// delocalizeLine rewrites a line with a label in a language other than English to its English form,
+// e.g. "特性：威吓" gives "Ability: 威吓" and "性格 固执" gives "固执 Nature". The names are kept.
+func delocalizeLine(line string) (string, bool) {
+	tr := loadTranslations()
+	line = strings.ReplaceAll(line, "：", ":")
+	for _, label := range tr.localLabels {
+		if len(line) > len(label.text) && strings.EqualFold(line[:len(label.text)], label.text) {
+			rest := line[len(label.text):]
+			colon := strings.HasPrefix(strings.TrimLeft(rest, " "), ":")
+			if !colon && rest[0] != ' ' {
+				continue
+			}
+			value := strings.TrimSpace(strings.TrimPrefix(strings.TrimLeft(rest, " "), ":"))
+			switch {
+			case len(value) == 0:
+				continue
+			case label.english == "Nature":
+				return value + " Nature", true
+			case !colon:
+				continue
+			case (label.english == "Shiny" || label.english == "Gigantamax") && tr.yes[strings.ToLower(value)]:
+				value = "Yes"
+			}
+			return label.english + ": " + value, true
+		}
+		if label.english == "Nature" && strings.HasSuffix(line, " "+label.text) {
+			if value := strings.TrimSpace(line[:len(line)-len(label.text)]); len(value) > 0 {
+				return value + " Nature", true
+			}
+		}
+	}
+	return line, false
+}
@@ -0,0 +1,181 @@
+package koffing
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const chineseSet = `瓦斯弹 @ 黑色污泥
+特性：飘浮
+太晶属性: 毒
+努力值: 252 HP / 4 防御 / 252 特防
+性格: 温和
+个体值: 0 攻击
+- 污泥炸弹
+- 鬼火
+- 守住
+- 觉醒力量 [冰]`
+
+func ExampleTranslate() {
+	team := Team{Pokemon: []Pokemon{{Name: "Koffing", Item: "Eviolite", Ability: "Levitate", Nature: "Bold", Moves: []string{"Will-O-Wisp", "Hidden Power [Fire]"}}}}
+	ja, untranslated, _ := Translate(team, English, Japanese)
+	p := ja.Pokemon[0]
+	fmt.Println(p.Name, p.Item, p.Ability, p.Nature, p.Moves)
+	// Eviolite is missing from the translation tables
+	fmt.Println(untranslated)
+	// Output:
+	// ドガース Eviolite ふゆう ずぶとい [おにび めざめるパワー [ほのお]]
+	// [{0 Item Eviolite}]
+}
+
+func ExamplePokemon_ToShowdownWithOptions() {
+	p := Pokemon{Name: "Koffing", Ability: "Levitate", Nature: "Bold", Happiness: 255, Evs: Stats{Hp: 252, Def: 252}, Ivs: Stats{31, 31, 31, 31, 31, 31}, Moves: []string{"Will-O-Wisp"}}
+	s, _ := p.ToShowdownWithOptions(ShowdownOptions{Language: German})
+	// Showdown ends every line with two spaces, which are trimmed here to fit in this comment
+	fmt.Print(strings.ReplaceAll(s, "  \n", "\n"))
+	// Output:
+	// Smogon
+	// Fähigkeit: Schwebe
+	// EVs: 252 KP / 252 Vert
+	// Wesen: Kühn
+	// - Irrlicht
+}
+
+func TestPokemon_FromShowdown_localized(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{}
+	warnings, err := p.FromShowdownWithOptions(chineseSet, ParseOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, Pokemon{
+		Name:         "Koffing",
+		Item:         "Black Sludge",
+		Ability:      "Levitate",
+		Happiness:    255,
+		DynamaxLevel: 10,
+		TeraType:     "Poison",
+		Nature:       "Calm",
+		Evs:          Stats{Hp: 252, Def: 4, Spd: 252},
+		Ivs:          Stats{Hp: 31, Atk: 0, Def: 31, Spa: 31, Spd: 31, Spe: 31},
+		Moves:        []string{"Sludge Bomb", "Will-O-Wisp", "Protect", "Hidden Power [Ice]"},
+	}, p)
+}
+
+func TestDelocalizeLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line string
+		want string
+		ok   bool
+	}{
+		{line: "特性: 威吓", want: "Ability: 威吓", ok: true},
+		{line: "特性：威吓", want: "Ability: 威吓", ok: true},
+		{line: "性格 固执", want: "固执 Nature", ok: true},
+		{line: "固执 性格", want: "固执 Nature", ok: true},
+		{line: "Nature: Rigide", want: "Rigide Nature", ok: true},
+		{line: "Niveau: 50", want: "Level: 50", ok: true},
+		{line: "Niveau Dynamax: 5", want: "Dynamax Level: 5", ok: true},
+		{line: "色違い: はい", want: "Shiny: Yes", ok: true},
+		{line: "Variocolor: Sí", want: "Shiny: Yes", ok: true},
+		{line: "Teratipo: Agua", want: "Tera Type: Agua", ok: true},
+		{line: "개체값: 0 공격", want: "IVs: 0 공격", ok: true},
+		{line: "Ball: Kirschball", want: "Pokeball: Kirschball", ok: true},
+		{line: "めざめるパワー: こおり", want: "Hidden Power: こおり", ok: true},
+		{line: "Talent Intimidation", ok: false},
+		{line: "特性:", ok: false},
+		{line: "Abilty: Levitate", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := delocalizeLine(tt.line)
+		assert.Equal(t, tt.ok, ok, tt.line)
+		if tt.ok {
+			assert.Equal(t, tt.want, got, tt.line)
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+	b, err := os.ReadFile("testdata/export/gen9vgc.txt")
+	assert.NoError(t, err)
+	team := Team{}
+	assert.NoError(t, team.FromShowdown(string(b)))
+	for _, lang := range languages {
+		translated, untranslated, err := Translate(team, English, lang)
+		assert.NoError(t, err)
+		assert.Empty(t, untranslated, lang)
+		back, untranslated, err := Translate(translated, lang, English)
+		assert.NoError(t, err)
+		assert.Empty(t, untranslated, lang)
+		assert.Equal(t, team, back, lang)
+
+		// localized text reads back as English
+		s, err := team.ToShowdownWithOptions(ShowdownOptions{Language: lang})
+		assert.NoError(t, err)
+		parsed := Team{}
+		warnings, err := parsed.FromShowdownWithOptions(s, ParseOptions{Strict: true})
+		assert.NoError(t, err, lang)
+		assert.Empty(t, warnings)
+		assert.Equal(t, team, parsed, lang)
+	}
+	// the labels of the Pokeball and Hidden Power lines are localized too
+	b, err = os.ReadFile("testdata/export/gen7hiddenpower.txt")
+	assert.NoError(t, err)
+	hp := Team{}
+	assert.NoError(t, hp.FromShowdown(string(b)))
+	for _, lang := range languages {
+		s, err := hp.ToShowdownWithOptions(ShowdownOptions{Language: lang})
+		assert.NoError(t, err)
+		if lang != English {
+			assert.NotContains(t, s, "Pokeball:", lang)
+			assert.NotContains(t, s, "\nHidden Power:", lang)
+		}
+		parsed := Team{}
+		_, err = parsed.FromShowdownWithOptions(s, ParseOptions{Strict: true})
+		assert.NoError(t, err, lang)
+		assert.Equal(t, hp, parsed, lang)
+	}
+
+	// the receiver is unchanged
+	ja, _, _ := Translate(team, English, Japanese)
+	assert.NotEqual(t, ja.Pokemon[0].Moves, team.Pokemon[0].Moves)
+
+	_, _, err = Translate(team, English, "xx")
+	assert.Error(t, err)
+	_, _, err = Translate(team, "", English)
+	assert.Error(t, err)
+	_, err = team.ToShowdownWithOptions(ShowdownOptions{Language: "xx"})
+	assert.Error(t, err)
+}
+
+func TestTranslate_untranslated(t *testing.T) {
+	t.Parallel()
+	team := Team{Pokemon: []Pokemon{
+		{Name: "Koffing", Item: "Eviolite", Ability: "Levitate", Moves: []string{"Will-O-Wisp", "Pain Split"}},
+		{Name: "Mew", Nickname: "Lax", Ability: "Synchronize", TeraType: "Ghost", Moves: []string{"Psychic", "Curse", "Hidden Power [Fire]", "Hidden Power [Bird]"}},
+	}}
+	fr, untranslated, err := Translate(team, English, French)
+	assert.NoError(t, err)
+	assert.Equal(t, []UntranslatedName{
+		{PokemonIndex: 0, Field: "Item", Name: "Eviolite"},
+		{PokemonIndex: 0, Field: "Moves", Name: "Pain Split"},
+		{PokemonIndex: 1, Field: "Name", Name: "Mew"},
+		{PokemonIndex: 1, Field: "Ability", Name: "Synchronize"},
+		{PokemonIndex: 1, Field: "Moves", Name: "Psychic"},
+		{PokemonIndex: 1, Field: "Moves", Name: "Hidden Power [Bird]"},
+	}, untranslated)
+	// the untranslated names are kept as is
+	assert.Equal(t, "Eviolite", fr.Pokemon[0].Item)
+	assert.Equal(t, "Lax", fr.Pokemon[1].Nickname)
+	assert.Equal(t, "Mew", fr.Pokemon[1].Name)
+	assert.Equal(t, []string{"Psychic", "Malédiction", "Puissance Cachée [Feu]", "Puissance Cachée [Bird]"}, fr.Pokemon[1].Moves)
+
+	// nothing is translated between the same languages
+	_, untranslated, err = Translate(team, English, English)
+	assert.NoError(t, err)
+	assert.Empty(t, untranslated)
+}
@@ -0,0 +1,229 @@
+// Command i18ngen generates the name tables of the translations, i18n/names.json, from the CSV data of PokeAPI.
+//
+// It reads languages.csv and the names of each category, e.g. pokemon_species_names.csv for the species.
+// The source is the URL of the data by default, or a directory holding copies of the files:
+//
+//	go run ./internal/i18ngen -src path/to/pokeapi/data/v2/csv
+//
+// The table is written to the directory given by -dst, which is i18n for go generate in package koffing.
+// Names without a translation in every language are left out, so that Translate reports them.
+// The labels of Showdown text in i18n/labels.json are maintained by hand.
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// languages are the languages of the table keyed by the identifiers of PokeAPI, in the order of the table.
+// Japanese is written in kana like in the games, and Chinese is simplified.
+var languages = []struct {
+	code, identifier string
+}{
+	{"en", "en"},
+	{"ja", "ja-Hrkt"},
+	{"zh", "zh-Hans"},
+	{"ko", "ko"},
+	{"fr", "fr"},
+	{"de", "de"},
+	{"es", "es"},
+}
+
+// categories are the categories of the table with the files of their names, in the order of the table.
+var categories = []struct {
+	name, file, idColumn string
+}{
+	{"species", "pokemon_species_names.csv", "pokemon_species_id"},
+	{"moves", "move_names.csv", "move_id"},
+	{"items", "item_names.csv", "item_id"},
+	{"abilities", "ability_names.csv", "ability_id"},
+	{"natures", "nature_names.csv", "nature_id"},
+	{"types", "type_names.csv", "type_id"},
+}
+
+func main() {
+	src := flag.String("src", "https://raw.githubusercontent.com/PokeAPI/pokeapi/master/data/v2/csv/", "URL or directory of the CSV data of PokeAPI")
+	dst := flag.String("dst", "i18n", "directory of the generated table")
+	flag.Parse()
+	if err := generate(*src, *dst); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func generate(src, dst string) error {
+	b, err := readSource(src, "languages.csv")
+	if err != nil {
+		return fmt.Errorf("languages.csv: %w", err)
+	}
+	codes, err := languageCodes(b)
+	if err != nil {
+		return fmt.Errorf("languages.csv: %w", err)
+	}
+	var out bytes.Buffer
+	out.WriteString("{\n")
+	for i, c := range categories {
+		b, err := readSource(src, c.file)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.file, err)
+		}
+		names, skipped, err := nameTable(b, c.idColumn, codes)
+		if err != nil {
+			return fmt.Errorf("%s: %w", c.file, err)
+		}
+		fmt.Fprintf(&out, " %q: [\n", c.name)
+		for j, entry := range names {
+			out.WriteString("  ")
+			out.Write(marshalEntry(entry))
+			if j < len(names)-1 {
+				out.WriteByte(',')
+			}
+			out.WriteByte('\n')
+		}
+		out.WriteString(" ]")
+		if i < len(categories)-1 {
+			out.WriteByte(',')
+		}
+		out.WriteByte('\n')
+		log.Printf("%s: %d names, %d without every translation", c.name, len(names), skipped)
+	}
+	out.WriteString("}\n")
+	return os.WriteFile(filepath.Join(dst, "names.json"), out.Bytes(), 0o644)
+}
+
+// languageCodes returns the codes of the languages of the table keyed by the IDs of the languages of PokeAPI.
+func languageCodes(src []byte) (map[string]string, error) {
+	rows, err := readCSV(src, "id", "identifier")
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string]string, len(languages))
+	for _, row := range rows {
+		for _, lang := range languages {
+			if row[1] == lang.identifier {
+				codes[row[0]] = lang.code
+			}
+		}
+	}
+	if len(codes) != len(languages) {
+		return nil, fmt.Errorf("found %d of the %d languages", len(codes), len(languages))
+	}
+	return codes, nil
+}
+
+// nameTable returns the names of a category in every language ordered by ID, and the number of names left out
+// because they lack a translation.
+func nameTable(src []byte, idColumn string, codes map[string]string) (names []map[string]string, skipped int, err error) {
+	rows, err := readCSV(src, idColumn, "local_language_id", "name")
+	if err != nil {
+		return nil, 0, err
+	}
+	byID := make(map[int]map[string]string)
+	for _, row := range rows {
+		id, err := strconv.Atoi(row[0])
+		if err != nil {
+			return nil, 0, fmt.Errorf("invalid %s: %w", idColumn, err)
+		}
+		code, ok := codes[row[1]]
+		if !ok || len(row[2]) == 0 {
+			continue
+		}
+		if byID[id] == nil {
+			byID[id] = make(map[string]string, len(languages))
+		}
+		byID[id][code] = strings.TrimSpace(row[2])
+	}
+	ids := make([]int, 0, len(byID))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	for _, id := range ids {
+		if len(byID[id]) < len(languages) {
+			skipped++
+			continue
+		}
+		names = append(names, byID[id])
+	}
+	return names, skipped, nil
+}
+
+// readCSV returns the values of the columns of each row of a CSV file with a header.
+func readCSV(src []byte, columns ...string) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(src))
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	index := make([]int, len(columns))
+	for i, column := range columns {
+		index[i] = -1
+		for j, name := range header {
+			if name == column {
+				index[i] = j
+			}
+		}
+		if index[i] < 0 {
+			return nil, fmt.Errorf("missing column %s", column)
+		}
+	}
+	var rows [][]string
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		row := make([]string, len(columns))
+		for i, j := range index {
+			row[i] = record[j]
+		}
+		rows = append(rows, row)
+	}
+}
+
+// marshalEntry returns the translations of a name as a JSON object with the languages in the order of the table.
+func marshalEntry(entry map[string]string) []byte {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, lang := range languages {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		fmt.Fprintf(&b, "%q: ", lang.code)
+		enc := json.NewEncoder(&b)
+		enc.SetEscapeHTML(false)
+		_ = enc.Encode(entry[lang.code]) // strings always encode
+		b.Truncate(b.Len() - 1)          // the line break of Encode
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
+
+// readSource reads a file of a base URL or directory.
+func readSource(base, name string) ([]byte, error) {
+	if !strings.HasPrefix(base, "http://") && !strings.HasPrefix(base, "https://") {
+		return os.ReadFile(filepath.Join(base, filepath.FromSlash(name)))
+	}
+	resp, err := http.Get(strings.TrimSuffix(base, "/") + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
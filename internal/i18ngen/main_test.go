@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const languagesCSV = `id,iso639,iso3166,identifier,official,order
+1,ja,jp,ja-Hrkt,1,1
+3,ko,kr,ko,1,4
+5,fr,fr,fr,1,8
+6,de,de,de,1,9
+7,es,es,es,1,10
+9,en,us,en,1,7
+11,ja,jp,ja,1,2
+12,zh,cn,zh-Hans,1,6
+`
+
+func Test_generate(t *testing.T) {
+	t.Parallel()
+	src := t.TempDir()
+	files := map[string]string{
+		"languages.csv": languagesCSV,
+		"pokemon_species_names.csv": "pokemon_species_id,local_language_id,name,genus\n" +
+			"109,1,ドガース,どくガスポケモン\n109,3,또가스,독가스포켓몬\n109,5,Smogo,Pokémon Gaz\n109,6,Smogon,Giftgas\n" +
+			"109,7,Koffing,Pokémon Gas Venenoso\n109,9,Koffing,Poison Gas Pokémon\n109,11,ドガース,どくガスポケモン\n109,12,瓦斯弹,毒气宝可梦\n" +
+			"1,9,Bulbasaur,Seed Pokémon\n1,1,フシギダネ,たねポケモン\n",
+		"move_names.csv":    "move_id,local_language_id,name\n",
+		"item_names.csv":    "item_id,local_language_id,name\n",
+		"ability_names.csv": "ability_id,local_language_id,name\n",
+		"nature_names.csv":  "nature_id,local_language_id,name\n",
+		"type_names.csv":    "type_id,local_language_id,name\n",
+	}
+	for name, data := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(src, name), []byte(data), 0o644))
+	}
+	dst := t.TempDir()
+	assert.NoError(t, generate(src, dst))
+	b, err := os.ReadFile(filepath.Join(dst, "names.json"))
+	assert.NoError(t, err)
+	// Bulbasaur lacks translations and is left out
+	assert.Equal(t, `{
+ "species": [
+  {"en": "Koffing", "ja": "ドガース", "zh": "瓦斯弹", "ko": "또가스", "fr": "Smogo", "de": "Smogon", "es": "Koffing"}
+ ],
+ "moves": [
+ ],
+ "items": [
+ ],
+ "abilities": [
+ ],
+ "natures": [
+ ],
+ "types": [
+ ]
+}
+`, string(b))
+
+	assert.NoError(t, os.Remove(filepath.Join(src, "type_names.csv")))
+	assert.Error(t, generate(src, dst))
+	assert.Error(t, generate(t.TempDir(), dst))
+}
+
+func Test_languageCodes(t *testing.T) {
+	t.Parallel()
+	codes, err := languageCodes([]byte(languagesCSV))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"1": "ja", "3": "ko", "5": "fr", "6": "de", "7": "es", "9": "en", "12": "zh"}, codes)
+	_, err = languageCodes([]byte("id,identifier\n9,en\n"))
+	assert.Error(t, err)
+	_, err = languageCodes([]byte("id,name\n9,en\n"))
+	assert.Error(t, err)
+}
+
+func Test_nameTable(t *testing.T) {
+	t.Parallel()
+	codes := map[string]string{"1": "ja", "3": "ko", "5": "fr", "6": "de", "7": "es", "9": "en", "12": "zh"}
+	src := "type_id,local_language_id,name\n" +
+		"18,9,Fairy\n18,1,フェアリー\n18,12,妖精\n18,3,페어리\n18,5,Fée\n18,6,Fee\n18,7,Hada\n18,8,Folletto\n" +
+		"2,9,Fighting\n2,1,かくとう\n2,12,格斗\n2,3,격투\n2,5,Combat\n2,6,Kampf\n2,7,Lucha\n" +
+		"10001,9,???\n"
+	names, skipped, err := nameTable([]byte(src), "type_id", codes)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+	assert.Equal(t, []map[string]string{
+		{"en": "Fighting", "ja": "かくとう", "zh": "格斗", "ko": "격투", "fr": "Combat", "de": "Kampf", "es": "Lucha"},
+		{"en": "Fairy", "ja": "フェアリー", "zh": "妖精", "ko": "페어리", "fr": "Fée", "de": "Fee", "es": "Hada"},
+	}, names)
+
+	_, _, err = nameTable([]byte("type_id,local_language_id,name\nx,9,Fairy\n"), "type_id", codes)
+	assert.Error(t, err)
+	_, _, err = nameTable([]byte("move_id,local_language_id,name\n"), "type_id", codes)
+	assert.Error(t, err)
+	_, _, err = nameTable([]byte("type_id,local_language_id,name\n1,\"9\n"), "type_id", codes)
+	assert.Error(t, err)
+}
+
+func Test_marshalEntry(t *testing.T) {
+	t.Parallel()
+	entry := map[string]string{"en": "Farfetch’d", "ja": "カモネギ", "zh": "大葱鸭", "ko": "파오리", "fr": "Canarticho", "de": "Porenta", "es": "Farfetch’d"}
+	assert.Equal(t, `{"en": "Farfetch’d", "ja": "カモネギ", "zh": "大葱鸭", "ko": "파오리", "fr": "Canarticho", "de": "Porenta", "es": "Farfetch’d"}`, string(marshalEntry(entry)))
+}
@@ -0,0 +1,8 @@
+package koffing
+
+import "github.com/txfs19260817/koffing-go/dex"
+
+// ItemData returns the data of the held item of the receiver, or false if it holds none or an unknown one.
+func (p Pokemon) ItemData() (dex.ItemData, bool) {
+	return dex.Item(p.Item)
+}
@@ -0,0 +1,28 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePokemon_ItemData() {
+	var p Pokemon
+	_ = p.FromShowdown(koffingSet)
+	item, _ := p.ItemData()
+	fmt.Println(item.Name, item.Fling)
+	// Output: Eviolite 40
+}
+
+func TestPokemon_ItemData(t *testing.T) {
+	t.Parallel()
+	item, ok := Pokemon{Item: "choice scarf"}.ItemData()
+	assert.True(t, ok)
+	assert.Equal(t, "Choice Scarf", item.Name)
+	assert.True(t, item.IsChoice)
+	for _, name := range []string{"", "Home"} {
+		_, ok := Pokemon{Item: name}.ItemData()
+		assert.False(t, ok, name)
+	}
+}
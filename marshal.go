@@ -0,0 +1,260 @@
+package koffing
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+)
+
+// pokemonJSON and teamJSON have the fields of Pokemon and Team without their methods,
+// so that encoding them does not call the methods below again.
+type (
+	pokemonJSON Pokemon
+	teamJSON    Team
+)
+
+// MarshalText implements encoding.TextMarshaler with the Showdown text of the receiver.
+func (p Pokemon) MarshalText() ([]byte, error) {
+	return p.AppendShowdown(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing Showdown text.
+func (p *Pokemon) UnmarshalText(text []byte) error {
+	return p.FromShowdown(string(text))
+}
+
+// MarshalJSON implements json.Marshaler. It encodes the receiver as a JSON object rather than Showdown text.
+func (p Pokemon) MarshalJSON() ([]byte, error) {
+	return json.Marshal(pokemonJSON(p))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Like FromShowdown, it defaults missing IVs to 31,
+// happiness to 255 and dynamax level to 10, and leaves a missing level at 0, i.e. unspecified.
+func (p *Pokemon) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var v Pokemon
+	v.setDefaults()
+	if err := json.Unmarshal(data, (*pokemonJSON)(&v)); err != nil {
+		return err
+	}
+	*p = v
+	return nil
+}
+
+// Value implements driver.Valuer with the JSON encoding of the receiver.
+func (p Pokemon) Value() (driver.Value, error) {
+	return p.ToJson()
+}
+
+// Scan implements sql.Scanner. It accepts the JSON encoding or the Showdown text of a Pokémon, and NULL as the zero value.
+func (p *Pokemon) Scan(src interface{}) error {
+	data, err := scannedBytes(src)
+	if err != nil || data == nil {
+		*p = Pokemon{}
+		return err
+	}
+	if isJSONObject(data) {
+		return p.UnmarshalJSON(data)
+	}
+	return p.UnmarshalText(data)
+}
+
+// MarshalText implements encoding.TextMarshaler with the Showdown text of the receiver.
+func (t Team) MarshalText() ([]byte, error) {
+	return t.AppendShowdown(nil)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler by parsing Showdown text.
+func (t *Team) UnmarshalText(text []byte) error {
+	return t.FromShowdown(string(text))
+}
+
+// MarshalJSON implements json.Marshaler. It encodes the receiver as a JSON object rather than Showdown text.
+func (t Team) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teamJSON(t))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The Pokémon get the same defaults as Pokemon.UnmarshalJSON.
+func (t *Team) UnmarshalJSON(data []byte) error {
+	if string(bytes.TrimSpace(data)) == "null" {
+		return nil
+	}
+	var v Team
+	if err := json.Unmarshal(data, (*teamJSON)(&v)); err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+// Value implements driver.Valuer with the JSON encoding of the receiver.
+func (t Team) Value() (driver.Value, error) {
+	return t.ToJson()
+}
+
+// Scan implements sql.Scanner. It accepts the JSON encoding or the Showdown text of a team, and NULL as the zero value.
+func (t *Team) Scan(src interface{}) error {
+	data, err := scannedBytes(src)
+	if err != nil || data == nil {
+		*t = Team{}
+		return err
+	}
+	if isJSONObject(data) {
+		return t.UnmarshalJSON(data)
+	}
+	return t.UnmarshalText(data)
+}
+
+// scannedBytes returns the content of a value scanned from a database, or nil for NULL.
+func scannedBytes(src interface{}) ([]byte, error) {
+	switch v := src.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		return v, nil
+	case string:
+		return []byte(v), nil
+	}
+	return nil, fmt.Errorf("cannot scan %T", src)
+}
+
+// isJSONObject reports whether data looks like a JSON object rather than Showdown text.
+func isJSONObject(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("{"))
+}
+
+// JSONOptions controls how Pokémon and teams are encoded to JSON. The zero value writes every field.
+type JSONOptions struct {
+	// OmitDefaults leaves out the fields holding the values that decoding fills in when they are missing,
+	// e.g. IVs of 31, happiness of 255, EVs of 0 and empty strings.
+	OmitDefaults bool
+}
+
+// compactPokemon is the JSON encoding of a Pokemon without default values.
+// The fields whose default is not the zero value are pointers, which are nil if they hold the default.
+type compactPokemon struct {
+	Name            string        `json:"name,omitempty"`
+	Nickname        string        `json:"nickname,omitempty"`
+	Gender          string        `json:"gender,omitempty"`
+	Item            string        `json:"item,omitempty"`
+	Ability         string        `json:"ability,omitempty"`
+	Level           int           `json:"level,omitempty"`
+	Shiny           bool          `json:"shiny,omitempty"`
+	Happiness       *int          `json:"happiness,omitempty"`
+	Pokeball        string        `json:"pokeball,omitempty"`
+	HiddenPowerType string        `json:"hpType,omitempty"`
+	DynamaxLevel    *int          `json:"dynamaxLevel,omitempty"`
+	Gigantamax      bool          `json:"gigantamax,omitempty"`
+	TeraType        string        `json:"teraType,omitempty"`
+	Nature          string        `json:"nature,omitempty"`
+	Evs             *compactStats `json:"evs,omitempty"`
+	Ivs             *compactStats `json:"ivs,omitempty"`
+	Moves           []string      `json:"moves,omitempty"`
+}
+
+// compactStats is the JSON encoding of Stats without default values.
+type compactStats struct {
+	Hp  *int `json:"hp,omitempty"`
+	Atk *int `json:"atk,omitempty"`
+	Def *int `json:"def,omitempty"`
+	Spa *int `json:"spa,omitempty"`
+	Spd *int `json:"spd,omitempty"`
+	Spe *int `json:"spe,omitempty"`
+}
+
+// compactTeam is the JSON encoding of a Team without default values.
+type compactTeam struct {
+	Name    string           `json:"name,omitempty"`
+	Format  string           `json:"format,omitempty"`
+	Folder  string           `json:"folder,omitempty"`
+	Pokemon []compactPokemon `json:"pokemon,omitempty"`
+}
+
+// ToJsonWithOptions is like ToJson, but encodes with opts.
+func (p Pokemon) ToJsonWithOptions(opts JSONOptions) (string, error) {
+	if !opts.OmitDefaults {
+		return p.ToJson()
+	}
+	return json.MarshalToString(p.compact())
+}
+
+// ToJsonWithOptions is like ToJson, but encodes with opts.
+func (t Team) ToJsonWithOptions(opts JSONOptions) (string, error) {
+	if !opts.OmitDefaults {
+		return t.ToJson()
+	}
+	return json.MarshalToString(t.compact())
+}
+
+// ToJsonWithOptions is like ToJson, but encodes with opts.
+func (c TeamCollection) ToJsonWithOptions(opts JSONOptions) (string, error) {
+	if !opts.OmitDefaults {
+		return c.ToJson()
+	}
+	teams := make([]compactTeam, 0, len(c.Teams))
+	for _, t := range c.Teams {
+		teams = append(teams, t.compact())
+	}
+	return json.MarshalToString(struct {
+		Teams []compactTeam `json:"teams"`
+	}{teams})
+}
+
+func (p Pokemon) compact() compactPokemon {
+	c := compactPokemon{
+		Name:            p.Name,
+		Nickname:        p.Nickname,
+		Gender:          p.Gender,
+		Item:            p.Item,
+		Ability:         p.Ability,
+		Level:           p.Level,
+		Shiny:           p.Shiny,
+		Happiness:       nonDefault(p.Happiness, 255),
+		Pokeball:        p.Pokeball,
+		HiddenPowerType: p.HiddenPowerType,
+		Gigantamax:      p.Gigantamax,
+		TeraType:        p.TeraType,
+		Nature:          p.Nature,
+		Evs:             p.Evs.compact(0),
+		Ivs:             p.Ivs.compact(31),
+		Moves:           p.Moves,
+	}
+	// a dynamax level of 0 is unset, which ToJson omits as well
+	if p.DynamaxLevel != 0 {
+		c.DynamaxLevel = nonDefault(p.DynamaxLevel, 10)
+	}
+	return c
+}
+
+func (t Team) compact() compactTeam {
+	c := compactTeam{Name: t.Name, Format: t.Format, Folder: t.Folder}
+	for _, p := range t.Pokemon {
+		c.Pokemon = append(c.Pokemon, p.compact())
+	}
+	return c
+}
+
+// compact returns the stats other than defaultValue, or nil if there is none.
+func (s Stats) compact(defaultValue int) *compactStats {
+	if s == (Stats{defaultValue, defaultValue, defaultValue, defaultValue, defaultValue, defaultValue}) {
+		return nil
+	}
+	return &compactStats{
+		Hp:  nonDefault(s.Hp, defaultValue),
+		Atk: nonDefault(s.Atk, defaultValue),
+		Def: nonDefault(s.Def, defaultValue),
+		Spa: nonDefault(s.Spa, defaultValue),
+		Spd: nonDefault(s.Spd, defaultValue),
+		Spe: nonDefault(s.Spe, defaultValue),
+	}
+}
+
+// nonDefault returns a pointer to v, or nil if v is defaultValue.
+func nonDefault(v, defaultValue int) *int {
+	if v == defaultValue {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,274 @@
+package koffing
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	stdjson "encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const koffingSet = `Smogon (Koffing) (F) @ Eviolite
+Level: 5
+Ability: Levitate
+EVs: 36 HP / 236 Def / 236 SpD
+Bold Nature
+- Will-O-Wisp
+- Pain Split
+`
+
+func ExampleTeam_MarshalJSON() {
+	type post struct {
+		Title string `json:"title"`
+		Team  Team   `json:"team"`
+	}
+	var in post
+	_ = stdjson.Unmarshal([]byte(`{"title":"Gas","team":{"name":"Example Team","pokemon":[{"name":"Koffing","ability":"Levitate","nature":"Bold","moves":["Haze"]}]}}`), &in)
+	fmt.Printf("%+v\n", in.Team.Pokemon[0].Ivs)
+	out, _ := stdjson.Marshal(in)
+	fmt.Println(string(out))
+	// Output: {Hp:31 Atk:31 Def:31 Spa:31 Spd:31 Spe:31}
+	// {"title":"Gas","team":{"name":"Example Team","pokemon":[{"name":"Koffing","nickname":"","gender":"","item":"","ability":"Levitate","level":0,"shiny":false,"happiness":255,"dynamaxLevel":10,"nature":"Bold","evs":{"hp":0,"atk":0,"def":0,"spa":0,"spd":0,"spe":0},"ivs":{"hp":31,"atk":31,"def":31,"spa":31,"spd":31,"spe":31},"moves":["Haze"]}]}}
+}
+
+func TestPokemon_MarshalText(t *testing.T) {
+	t.Parallel()
+	p := &Pokemon{}
+	assert.NoError(t, p.UnmarshalText([]byte(koffingSet)))
+	text, err := p.MarshalText()
+	assert.NoError(t, err)
+	roundTrip := &Pokemon{}
+	assert.NoError(t, roundTrip.UnmarshalText(text))
+	assert.Equal(t, p, roundTrip)
+
+	_, err = Pokemon{}.MarshalText()
+	assert.Error(t, err)
+	assert.Error(t, p.UnmarshalText([]byte("Koffing")))
+}
+
+func TestTeam_MarshalText(t *testing.T) {
+	t.Parallel()
+	team := &Team{}
+	assert.NoError(t, team.UnmarshalText([]byte("=== [gen7] Gas ===\n\n"+koffingSet)))
+	assert.Equal(t, "Gas", team.Name)
+	text, err := team.MarshalText()
+	assert.NoError(t, err)
+	roundTrip := &Team{}
+	assert.NoError(t, roundTrip.UnmarshalText(text))
+	assert.Equal(t, team, roundTrip)
+	_, err = Team{Pokemon: []Pokemon{{}}}.MarshalText()
+	assert.Error(t, err)
+}
+
+func TestPokemon_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		j    string
+		want Pokemon
+	}{
+		{
+			name: "defaults",
+			j:    `{"name":"Koffing"}`,
+			want: Pokemon{Name: "Koffing", Happiness: 255, DynamaxLevel: 10, Ivs: Stats{31, 31, 31, 31, 31, 31}},
+		},
+		{
+			name: "partial IVs",
+			j:    `{"name":"Koffing","happiness":0,"dynamaxLevel":0,"ivs":{"atk":0,"spe":30}}`,
+			want: Pokemon{Name: "Koffing", Ivs: Stats{31, 0, 31, 31, 31, 30}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Pokemon
+			assert.NoError(t, stdjson.Unmarshal([]byte(tt.j), &p))
+			assert.Equal(t, tt.want, p)
+			p = Pokemon{}
+			assert.NoError(t, p.FromJson(tt.j))
+			assert.Equal(t, tt.want, p)
+		})
+	}
+
+	p := Pokemon{Name: "Koffing"}
+	assert.NoError(t, stdjson.Unmarshal([]byte("null"), &p))
+	assert.Equal(t, Pokemon{Name: "Koffing"}, p)
+	assert.Error(t, stdjson.Unmarshal([]byte(`{"name":1}`), &p))
+	assert.Error(t, stdjson.Unmarshal([]byte(`{"pokemon":1}`), &Team{}))
+}
+
+func TestTeam_MarshalJSON(t *testing.T) {
+	t.Parallel()
+	type row struct {
+		Team    Team     `json:"team"`
+		Pokemon *Pokemon `json:"pokemon"`
+	}
+	c := TeamCollection{}
+	assert.NoError(t, c.FromShowdown(backup))
+	team := c.Teams[0]
+	in := row{Team: team, Pokemon: &team.Pokemon[1]}
+	j, err := stdjson.Marshal(in)
+	assert.NoError(t, err)
+	assert.Contains(t, string(j), `"team":{"name":"Charizard Rain"`)
+	var out row
+	assert.NoError(t, stdjson.Unmarshal(j, &out))
+	assert.Equal(t, in, out)
+
+	// the package JSON functions agree with encoding/json
+	s, err := team.ToJson()
+	assert.NoError(t, err)
+	b, err := stdjson.Marshal(team)
+	assert.NoError(t, err)
+	assert.JSONEq(t, s, string(b))
+}
+
+func TestTeam_Scan(t *testing.T) {
+	t.Parallel()
+	var _ sql.Scanner = &Team{}
+	var _ driver.Valuer = Team{}
+	team := Team{}
+	assert.NoError(t, team.FromShowdown("=== [gen7] Gas ===\n\n"+koffingSet))
+
+	v, err := team.Value()
+	assert.NoError(t, err)
+	for _, src := range []interface{}{v, []byte(v.(string)), "=== [gen7] Gas ===\n\n" + koffingSet} {
+		scanned := Team{}
+		assert.NoError(t, scanned.Scan(src))
+		assert.Equal(t, team, scanned)
+	}
+
+	assert.NoError(t, team.Scan(nil))
+	assert.Equal(t, Team{}, team)
+	assert.Error(t, team.Scan(42))
+	assert.Error(t, team.Scan("{"))
+}
+
+func TestPokemon_Scan(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{}
+	assert.NoError(t, p.FromShowdown(koffingSet))
+	v, err := p.Value()
+	assert.NoError(t, err)
+	for _, src := range []interface{}{v, []byte(koffingSet)} {
+		scanned := Pokemon{}
+		assert.NoError(t, scanned.Scan(src))
+		assert.Equal(t, p, scanned)
+	}
+	assert.NoError(t, p.Scan(nil))
+	assert.Equal(t, Pokemon{}, p)
+	assert.Error(t, p.Scan(3.14))
+}
+
+func ExamplePokemon_ToJsonWithOptions() {
+	p := Pokemon{}
+	_ = p.FromShowdown(koffingSet)
+	j, _ := p.ToJsonWithOptions(JSONOptions{OmitDefaults: true})
+	fmt.Println(j)
+	// Output: {"name":"Koffing","nickname":"Smogon","gender":"F","item":"Eviolite","ability":"Levitate","level":5,"nature":"Bold","evs":{"hp":36,"def":236,"spd":236},"moves":["Will-O-Wisp","Pain Split"]}
+}
+
+func TestPokemon_ToJsonWithOptions(t *testing.T) {
+	t.Parallel()
+	c := TeamCollection{}
+	assert.NoError(t, c.FromShowdown(backup))
+	pokemon := []Pokemon{
+		{},
+		{Name: "Koffing", DynamaxLevel: 3, Happiness: 0, Ivs: Stats{Atk: 31}},
+		{Name: "Koffing", DynamaxLevel: 10, Happiness: 255, Ivs: Stats{31, 31, 31, 31, 31, 31}, Evs: Stats{Spe: 4}},
+	}
+	for _, team := range c.Teams {
+		pokemon = append(pokemon, team.Pokemon...)
+	}
+	for _, p := range pokemon {
+		full, err := p.ToJson()
+		assert.NoError(t, err)
+		compact, err := p.ToJsonWithOptions(JSONOptions{OmitDefaults: true})
+		assert.NoError(t, err)
+		assert.LessOrEqual(t, len(compact), len(full))
+		// both encodings decode to the same Pokémon
+		want, got := Pokemon{}, Pokemon{}
+		assert.NoError(t, want.FromJson(full))
+		assert.NoError(t, got.FromJson(compact))
+		assert.Equal(t, want, got, compact)
+	}
+
+	j, err := pokemon[2].ToJsonWithOptions(JSONOptions{OmitDefaults: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Koffing","evs":{"spe":4}}`, j)
+	j, err = pokemon[1].ToJsonWithOptions(JSONOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, j, `"ivs":{"hp":0,"atk":31,"def":0,"spa":0,"spd":0,"spe":0}`)
+}
+
+func TestTeamCollection_ToJsonWithOptions(t *testing.T) {
+	t.Parallel()
+	c := TeamCollection{}
+	assert.NoError(t, c.FromShowdown(backup))
+	full, err := c.ToJson()
+	assert.NoError(t, err)
+	compact, err := c.ToJsonWithOptions(JSONOptions{OmitDefaults: true})
+	assert.NoError(t, err)
+	assert.Less(t, len(compact), len(full))
+	assert.NotContains(t, compact, `"happiness"`)
+
+	roundTrip := TeamCollection{}
+	assert.NoError(t, roundTrip.FromJson(compact))
+	assert.Equal(t, c, roundTrip)
+
+	team, err := c.Teams[1].ToJsonWithOptions(JSONOptions{OmitDefaults: true})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"Example Team","format":"gen7","folder":"Folder 1/Sub Folder","pokemon":[{"name":"Koffing","nickname":"Smogon","gender":"F","item":"Eviolite","ability":"Levitate","level":5,"nature":"Bold","evs":{"hp":36,"def":236,"spd":236},"moves":["Will-O-Wisp","Pain Split"]}]}`, team)
+	team, err = c.Teams[1].ToJsonWithOptions(JSONOptions{})
+	assert.NoError(t, err)
+	assert.Contains(t, team, `"happiness":255`)
+}
+
+func FuzzPokemon_UnmarshalText(f *testing.F) {
+	f.Add([]byte(koffingSet))
+	f.Add([]byte(""))
+	f.Add([]byte("Koffing\n- Haze"))
+	f.Fuzz(func(t *testing.T, text []byte) {
+		p := &Pokemon{}
+		if err := p.UnmarshalText(text); err != nil {
+			return
+		}
+		_, _ = p.MarshalText()
+		exportPokemon(*p)
+	})
+}
+
+func FuzzTeam_UnmarshalText(f *testing.F) {
+	f.Add([]byte(koffingSet))
+	f.Add([]byte(backup))
+	f.Add([]byte("=== [gen8] ==="))
+	f.Fuzz(func(t *testing.T, text []byte) {
+		team := &Team{}
+		if err := team.UnmarshalText(text); err != nil {
+			return
+		}
+		_, _ = team.MarshalText()
+		exportTeam(*team)
+	})
+}
+
+func FuzzScan(f *testing.F) {
+	f.Add([]byte(koffingSet))
+	f.Add([]byte(`{"name":"Koffing","moves":["Haze"]}`))
+	f.Add([]byte(`{"pokemon":[{"name":"Koffing"}]}`))
+	f.Add([]byte(" {"))
+	f.Fuzz(func(t *testing.T, data []byte) {
+		for _, src := range []interface{}{data, string(data)} {
+			p := &Pokemon{}
+			if err := p.Scan(src); err == nil {
+				_, _ = p.Value()
+				exportPokemon(*p)
+			}
+			team := &Team{}
+			if err := team.Scan(src); err == nil {
+				_, _ = team.Value()
+				exportTeam(*team)
+			}
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package koffing
+
+import "github.com/txfs19260817/koffing-go/dex"
+
+// Move is a move of a set, with the type of Hidden Power parsed out of its name.
+type Move struct {
+	// Name is the name of the move, which is "Hidden Power" for Hidden Power of any type.
+	Name string `json:"name"`
+	// HiddenPowerType is the type of Hidden Power, e.g. Fire for "Hidden Power [Fire]". It is empty for other moves.
+	HiddenPowerType string `json:"hiddenPowerType,omitempty"`
+}
+
+// ParseMove returns the move of a name like in Showdown text, e.g. "Hidden Power [Fire]" gives Hidden Power of type Fire.
+func ParseMove(name string) Move {
+	if typ, ok := ParseHiddenPower(name); ok {
+		return Move{Name: "Hidden Power", HiddenPowerType: typ}
+	}
+	return Move{Name: name}
+}
+
+// IsHiddenPower reports whether the move is Hidden Power.
+func (m Move) IsHiddenPower() bool {
+	return m.Name == "Hidden Power"
+}
+
+// String returns the name of the move like in Showdown text, e.g. "Hidden Power [Fire]".
+func (m Move) String() string {
+	if len(m.HiddenPowerType) > 0 {
+		return m.Name + " [" + m.HiddenPowerType + "]"
+	}
+	return m.Name
+}
+
+// TypedMoves returns the moves of the receiver in the order of Moves, see ParseMove.
+func (p Pokemon) TypedMoves() []Move {
+	moves := make([]Move, len(p.Moves))
+	for i, move := range p.Moves {
+		moves[i] = ParseMove(move)
+	}
+	return moves
+}
+
+// MoveData returns the data of the moves of the receiver in the latest generation, in the order of Moves.
+// The data of an unknown move are the zero value, whose Name is empty.
+// The type of Hidden Power is the one in its name, e.g. Fire for "Hidden Power [Fire]".
+func (p Pokemon) MoveData() []dex.MoveData {
+	data := make([]dex.MoveData, len(p.Moves))
+	for i, move := range p.TypedMoves() {
+		data[i], _ = dex.Move(move.Name)
+		if known, ok := hiddenPowerType(move.HiddenPowerType); ok {
+			data[i].Type = known
+		}
+	}
+	return data
+}
@@ -0,0 +1,91 @@
+package koffing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+func ExamplePokemon_MoveData() {
+	var p Pokemon
+	_ = p.FromShowdown(koffingSet)
+	for _, m := range p.MoveData() {
+		fmt.Println(m.Name, m.Type, m.Category, m.Accuracy)
+	}
+	// Output:
+	// Will-O-Wisp Fire Status 85
+	// Pain Split Normal Status 0
+}
+
+func ExamplePokemon_TypedMoves() {
+	p := Pokemon{Moves: []string{"Thunderbolt", "hidden power [ice]"}}
+	for _, m := range p.TypedMoves() {
+		fmt.Printf("%s %q %s\n", m.Name, m.HiddenPowerType, m)
+	}
+	// Output:
+	// Thunderbolt "" Thunderbolt
+	// Hidden Power "Ice" Hidden Power [Ice]
+}
+
+func TestParseMove(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		want Move
+	}{
+		{name: "Hidden Power [Fire]", want: Move{Name: "Hidden Power", HiddenPowerType: "Fire"}},
+		{name: "Hidden Power", want: Move{Name: "Hidden Power"}},
+		{name: "Hidden Power [Fairy]", want: Move{Name: "Hidden Power", HiddenPowerType: "Fairy"}},
+		{name: "Power Gem", want: Move{Name: "Power Gem"}},
+	}
+	for _, tt := range tests {
+		m := ParseMove(tt.name)
+		assert.Equal(t, tt.want, m, tt.name)
+		assert.Equal(t, tt.name, m.String())
+	}
+	assert.True(t, ParseMove("Hidden Power Ice").IsHiddenPower())
+	assert.False(t, ParseMove("Power Gem").IsHiddenPower())
+}
+
+func TestPokemon_MoveData(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{Moves: []string{"Thunderbolt", "hidden power [ice]", "Hidden Power", "Splash", "Close Combat"}}
+	data := p.MoveData()
+	assert.Len(t, data, 5)
+	assert.Equal(t, "Thunderbolt", data[0].Name)
+	assert.Equal(t, 90, data[0].BasePower)
+	assert.Equal(t, "Hidden Power", data[1].Name)
+	assert.Equal(t, "Ice", data[1].Type)
+	assert.Equal(t, "Normal", data[2].Type)
+	assert.Empty(t, data[3].Name)
+	assert.True(t, data[4].Flags.Contact)
+	assert.Empty(t, Pokemon{}.MoveData())
+}
+
+// TestPokemon_MoveData_testdata checks that the moves of the teams in testdata are in the move data.
+func TestPokemon_MoveData_testdata(t *testing.T) {
+	t.Parallel()
+	files, err := filepath.Glob(filepath.Join("testdata", "*", "*.txt"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		c := TeamCollection{}
+		assert.NoError(t, c.FromShowdown(string(b)), file)
+		for _, team := range c.Teams {
+			for _, p := range team.Pokemon {
+				for i, m := range p.TypedMoves() {
+					_, err := dex.Resolve(dex.KindMove, m.Name)
+					assert.NoError(t, err, file)
+					assert.Equal(t, m.Name, p.MoveData()[i].Name, file)
+				}
+			}
+		}
+	}
+}
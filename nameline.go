@@ -0,0 +1,82 @@
+package koffing
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// span is a byte range of a line, which is empty for a missing part.
+type span struct {
+	start, end int
+}
+
+// of returns the part of the line in the span.
+func (s span) of(line string) string {
+	return line[s.start:s.end]
+}
+
+// nameTokens are the parts of the name line of a set, "Nickname (Species) (F) @ Item".
+type nameTokens struct {
+	nickname, species, gender, item span
+}
+
+// tokenizeNameLine splits the trimmed name line of a set from right to left like the importer of Pokémon Showdown does:
+// the item follows the last " @ ", then a trailing " (M)" or " (F)" is the gender,
+// and if the rest ends with ")", the species is enclosed by the last " (" and the nickname precedes it.
+// Thus nicknames may hold parentheses and "@", e.g. "(@_@) (Koffing)", while "Mr. Mime (M)" is a species with a gender.
+func tokenizeNameLine(line string) nameTokens {
+	var t nameTokens
+	end := len(line)
+	if at := strings.LastIndex(line, " @ "); at >= 0 {
+		t.item = trimSpan(line, span{at + len(" @ "), len(line)})
+		end = at
+	} else if strings.HasSuffix(line, " @") {
+		end -= len(" @")
+	}
+	end = trimSpan(line, span{0, end}).end
+	if rest := line[:end]; strings.HasSuffix(rest, " (M)") || strings.HasSuffix(rest, " (F)") {
+		t.gender = span{end - 2, end - 1}
+		end = trimSpan(line, span{0, end - len(" (M)")}).end
+	}
+	t.species = span{0, end}
+	if rest := line[:end]; strings.HasSuffix(rest, ")") {
+		if open := strings.LastIndex(rest, " ("); open >= 0 {
+			t.species = trimSpan(line, span{open + len(" ("), end - len(")")})
+			t.nickname = trimSpan(line, span{0, open})
+		}
+	}
+	return t
+}
+
+// trimSpan returns the span without its surrounding spaces.
+func trimSpan(line string, s span) span {
+	part := line[s.start:s.end]
+	start := s.start + len(part) - len(strings.TrimLeftFunc(part, unicode.IsSpace))
+	end := s.start + len(strings.TrimRightFunc(part, unicode.IsSpace))
+	if end < start {
+		end = start
+	}
+	return span{start, end}
+}
+
+// parseNameLine sets the nickname, species, gender and item of the receiver from the trimmed name line of a set.
+// On failure, offset is the position of the offending part in the line.
+func (p *Pokemon) parseNameLine(line string) (offset int, err error) {
+	t := tokenizeNameLine(line)
+	species := t.species.of(line)
+	// no species holds "@" or parentheses, which would be left over by a malformed line
+	if strings.IndexFunc(species, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) < 0 ||
+		strings.ContainsAny(species, "@()") {
+		return t.species.start, fmt.Errorf("invalid name: %s", line)
+	}
+	p.Name, p.Nickname = species, t.nickname.of(line)
+	if t.gender.end > 0 {
+		p.Gender = t.gender.of(line)
+	}
+	// Showdown reads "No Item" as no item
+	if item := t.item.of(line); !strings.EqualFold(item, "No Item") {
+		p.Item = item
+	}
+	return 0, nil
+}
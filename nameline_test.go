@@ -0,0 +1,111 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Example_tokenizeNameLine() {
+	line := "Sir (Chomps) @ Work (Garchomp) (M) @ Life Orb"
+	t := tokenizeNameLine(line)
+	fmt.Printf("%q %q %q %q\n", t.nickname.of(line), t.species.of(line), t.gender.of(line), t.item.of(line))
+	// Output: "Sir (Chomps) @ Work" "Garchomp" "M" "Life Orb"
+}
+
+func TestPokemon_parseNameLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line    string
+		want    Pokemon
+		wantErr bool
+	}{
+		// plain
+		{line: "Koffing", want: Pokemon{Name: "Koffing"}},
+		{line: "Koffing @ Eviolite", want: Pokemon{Name: "Koffing", Item: "Eviolite"}},
+		{line: "Koffing (F)", want: Pokemon{Name: "Koffing", Gender: "F"}},
+		{line: "Koffing (M) @ Eviolite", want: Pokemon{Name: "Koffing", Gender: "M", Item: "Eviolite"}},
+		{line: "Smogon (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "Smogon"}},
+		{line: "Smogon (Koffing) (F) @ Eviolite", want: Pokemon{Name: "Koffing", Nickname: "Smogon", Gender: "F", Item: "Eviolite"}},
+		{line: "Tapu Koko (Weezing-Gmax) (F) @ Eviolite", want: Pokemon{Name: "Weezing-Gmax", Nickname: "Tapu Koko", Gender: "F", Item: "Eviolite"}},
+		// species with unusual characters
+		{line: "Mr. Mime (M)", want: Pokemon{Name: "Mr. Mime", Gender: "M"}},
+		{line: "Mr. Mime-Galar @ Choice Scarf", want: Pokemon{Name: "Mr. Mime-Galar", Item: "Choice Scarf"}},
+		{line: "Nidoran-F", want: Pokemon{Name: "Nidoran-F"}},
+		{line: "Nidoran-M (M)", want: Pokemon{Name: "Nidoran-M", Gender: "M"}},
+		{line: "Type: Null @ Eviolite", want: Pokemon{Name: "Type: Null", Item: "Eviolite"}},
+		{line: "Null (Type: Null)", want: Pokemon{Name: "Type: Null", Nickname: "Null"}},
+		{line: "Farfetch’d @ Leek", want: Pokemon{Name: "Farfetch’d", Item: "Leek"}},
+		{line: "Sirfetch'd (M)", want: Pokemon{Name: "Sirfetch'd", Gender: "M"}},
+		{line: "Flabébé (F) @ Eviolite", want: Pokemon{Name: "Flabébé", Gender: "F", Item: "Eviolite"}},
+		{line: "Porygon-Z @ Choice Specs", want: Pokemon{Name: "Porygon-Z", Item: "Choice Specs"}},
+		{line: "Ho-Oh", want: Pokemon{Name: "Ho-Oh"}},
+		{line: "Mew", want: Pokemon{Name: "Mew"}},
+		{line: "瓦斯弹 @ Eviolite", want: Pokemon{Name: "瓦斯弹", Item: "Eviolite"}},
+		// tricky nicknames
+		{line: "(M) (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "(M)"}},
+		{line: "(F) (Koffing) (M)", want: Pokemon{Name: "Koffing", Nickname: "(F)", Gender: "M"}},
+		{line: "(@_@) (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "(@_@)"}},
+		{line: "Gas (Leak) (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "Gas (Leak)"}},
+		{line: "me@home (Koffing) @ Eviolite", want: Pokemon{Name: "Koffing", Nickname: "me@home", Item: "Eviolite"}},
+		{line: "me @ home (Koffing) @ Eviolite", want: Pokemon{Name: "Koffing", Nickname: "me @ home", Item: "Eviolite"}},
+		{line: "Koffing (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "Koffing"}},
+		{line: "=== (Koffing)", want: Pokemon{Name: "Koffing", Nickname: "==="}},
+		{line: "M (Mew) (M)", want: Pokemon{Name: "Mew", Nickname: "M", Gender: "M"}},
+		{line: "Bob  (Koffing)  (F)", want: Pokemon{Name: "Koffing", Nickname: "Bob", Gender: "F"}},
+		// genders are upper case, thus Showdown reads "f" as a species too
+		{line: "Koffing (f)", want: Pokemon{Name: "f", Nickname: "Koffing"}},
+		// items
+		{line: "Koffing @ No Item", want: Pokemon{Name: "Koffing"}},
+		{line: "Koffing @", want: Pokemon{Name: "Koffing"}},
+		{line: "Koffing @  Eviolite", want: Pokemon{Name: "Koffing", Item: "Eviolite"}},
+		{line: "Koffing @ King's Rock", want: Pokemon{Name: "Koffing", Item: "King's Rock"}},
+		// no space before the parenthesis or after "@", which Showdown does not read as such
+		{line: "Koffing(F)", wantErr: true},
+		{line: "Smogon(Koffing)", wantErr: true},
+		{line: "Koffing@Eviolite", wantErr: true},
+		// malformed
+		{line: "@ Sitrus Berry", wantErr: true},
+		{line: "(@)", wantErr: true},
+		{line: "Smogon ()", wantErr: true},
+		{line: "Smogon ( )", wantErr: true},
+		{line: "Smogon (Koffing", wantErr: true},
+		{line: "(M)", wantErr: true},
+		{line: "- @ -", wantErr: true},
+		{line: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		p := Pokemon{}
+		_, err := p.parseNameLine(tt.line)
+		if tt.wantErr {
+			assert.Error(t, err, tt.line)
+			continue
+		}
+		if assert.NoError(t, err, tt.line) {
+			assert.Equal(t, tt.want, p, tt.line)
+		}
+	}
+}
+
+func TestPokemon_ToShowdown_nameLine(t *testing.T) {
+	t.Parallel()
+	// every name line written by ToShowdown reads back the same
+	for _, p := range []Pokemon{
+		{Name: "Koffing", Nickname: "(@_@)", Gender: "F", Item: "Eviolite"},
+		// without an item, Showdown would read " @ Home" as the item too
+		{Name: "Koffing", Nickname: "Gas (Leak) @ Home", Item: "Eviolite"},
+		{Name: "Mr. Mime", Gender: "M"},
+		{Name: "Type: Null", Nickname: "M", Item: "Eviolite"},
+	} {
+		p.Happiness, p.Ivs, p.Moves = 255, Stats{31, 31, 31, 31, 31, 31}, []string{"Tackle", "Smog"}
+		s, err := p.ToShowdown()
+		assert.NoError(t, err)
+		got := Pokemon{}
+		assert.NoError(t, got.FromShowdown(s))
+		assert.Equal(t, p.Name, got.Name)
+		assert.Equal(t, p.Nickname, got.Nickname)
+		assert.Equal(t, p.Gender, got.Gender)
+		assert.Equal(t, p.Item, got.Item)
+	}
+}
@@ -0,0 +1,150 @@
+package koffing
+
+import (
+	"fmt"
+
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+// Nature is one of the 25 natures, which raise a stat by 10% and lower another one by 10%, except for the 5 neutral ones.
+// The constants are in the order of the games. The zero value is no nature, like in gens 1 and 2.
+type Nature uint8
+
+const (
+	Hardy Nature = iota + 1
+	Lonely
+	Brave
+	Adamant
+	Naughty
+	Bold
+	Docile
+	Relaxed
+	Impish
+	Lax
+	Timid
+	Hasty
+	Serious
+	Jolly
+	Naive
+	Modest
+	Mild
+	Quiet
+	Bashful
+	Rash
+	Calm
+	Gentle
+	Sassy
+	Careful
+	Quirky
+)
+
+var natureStrings = [...]string{
+	"Hardy", "Lonely", "Brave", "Adamant", "Naughty",
+	"Bold", "Docile", "Relaxed", "Impish", "Lax",
+	"Timid", "Hasty", "Serious", "Jolly", "Naive",
+	"Modest", "Mild", "Quiet", "Bashful", "Rash",
+	"Calm", "Gentle", "Sassy", "Careful", "Quirky",
+}
+
+// natureStats are the labels of the stats natures modify. The nature of index i raises natureStats[i/5] and lowers natureStats[i%5].
+var natureStats = [5]string{"Atk", "Def", "Spe", "SpA", "SpD"}
+
+// Natures returns the 25 natures in the order of the games.
+func Natures() []Nature {
+	natures := make([]Nature, len(natureStrings))
+	for i := range natures {
+		natures[i] = Nature(i + 1)
+	}
+	return natures
+}
+
+// ParseNature returns the nature of a name regardless of case, spaces and punctuation, e.g. "bold" gives Bold.
+// For an unknown name, the error suggests the closest natures, e.g. "unknown nature: Bald (did you mean Bold?)".
+func ParseNature(name string) (Nature, error) {
+	e, err := dex.Resolve(dex.KindNature, name)
+	if err != nil {
+		return 0, err
+	}
+	for i, s := range natureStrings {
+		if s == e.Name {
+			return Nature(i + 1), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown nature: %s", name)
+}
+
+// Valid reports whether the receiver is one of the 25 natures.
+func (n Nature) Valid() bool {
+	return n >= Hardy && n <= Quirky
+}
+
+// String returns the name of the nature, e.g. "Bold", or an empty string for no nature.
+func (n Nature) String() string {
+	switch {
+	case n == 0:
+		return ""
+	case n.Valid():
+		return natureStrings[n-1]
+	}
+	return fmt.Sprintf("Nature(%d)", int(n))
+}
+
+// Neutral reports whether the nature modifies no stat, e.g. Hardy.
+func (n Nature) Neutral() bool {
+	return n.Valid() && (n-1)/5 == (n-1)%5
+}
+
+// Boosted returns the label of the stat raised by the nature as in EVs lines, e.g. "SpA" for Modest,
+// or an empty string if it is neutral.
+func (n Nature) Boosted() string {
+	if !n.Valid() || n.Neutral() {
+		return ""
+	}
+	return natureStats[(n-1)/5]
+}
+
+// Hindered returns the label of the stat lowered by the nature as in EVs lines, e.g. "Atk" for Modest,
+// or an empty string if it is neutral.
+func (n Nature) Hindered() string {
+	if !n.Valid() || n.Neutral() {
+		return ""
+	}
+	return natureStats[(n-1)%5]
+}
+
+// Multiplier returns the factor of a stat by the nature, i.e. 1.1 if it is boosted, 0.9 if it is hindered and 1 otherwise.
+// The stat is a name like in EVs lines, e.g. "SpA", "Sp. Atk" or "Special Attack".
+func (n Nature) Multiplier(stat string) float64 {
+	label, ok := statLabel(stat)
+	switch {
+	case !ok:
+		return 1
+	case label == n.Boosted():
+		return 1.1
+	case label == n.Hindered():
+		return 0.9
+	}
+	return 1
+}
+
+// MarshalText implements encoding.TextMarshaler, encoding the name of the nature, and so does JSON.
+func (n Nature) MarshalText() ([]byte, error) {
+	if n != 0 && !n.Valid() {
+		return nil, fmt.Errorf("invalid nature: %d", int(n))
+	}
+	return []byte(n.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler with ParseNature, decoding an empty text as no nature.
+func (n *Nature) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*n = 0
+		return nil
+	}
+	nature, err := ParseNature(string(text))
+	if err != nil {
+		return err
+	}
+	*n = nature
+	return nil
+}
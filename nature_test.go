@@ -0,0 +1,172 @@
+package koffing
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleNature() {
+	for _, name := range []string{"Modest", "hardy", "Bald"} {
+		n, err := ParseNature(name)
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		fmt.Println(n, n.Neutral(), n.Boosted(), n.Hindered(), n.Multiplier("Sp. Atk"))
+	}
+	// Output:
+	// Modest false SpA Atk 1.1
+	// Hardy true   1
+	// unknown nature: Bald (did you mean Bold?)
+}
+
+func TestNatures(t *testing.T) {
+	t.Parallel()
+	natures := Natures()
+	assert.Len(t, natures, 25)
+	boosted, hindered, neutral := map[string]int{}, map[string]int{}, 0
+	for i, n := range natures {
+		assert.True(t, n.Valid())
+		assert.Equal(t, Nature(i+1), n)
+		parsed, err := ParseNature(n.String())
+		assert.NoError(t, err)
+		assert.Equal(t, n, parsed)
+		if n.Neutral() {
+			neutral++
+			assert.Empty(t, n.Boosted())
+			assert.Empty(t, n.Hindered())
+			continue
+		}
+		assert.NotEqual(t, n.Boosted(), n.Hindered(), n)
+		boosted[n.Boosted()]++
+		hindered[n.Hindered()]++
+	}
+	// each stat but HP is boosted by 4 natures and hindered by 4 others
+	assert.Equal(t, 5, neutral)
+	assert.Equal(t, map[string]int{"Atk": 4, "Def": 4, "SpA": 4, "SpD": 4, "Spe": 4}, boosted)
+	assert.Equal(t, boosted, hindered)
+}
+
+func TestNature(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		nature   Nature
+		name     string
+		boosted  string
+		hindered string
+	}{
+		{Lonely, "Lonely", "Atk", "Def"},
+		{Brave, "Brave", "Atk", "Spe"},
+		{Adamant, "Adamant", "Atk", "SpA"},
+		{Bold, "Bold", "Def", "Atk"},
+		{Timid, "Timid", "Spe", "Atk"},
+		{Jolly, "Jolly", "Spe", "SpA"},
+		{Quiet, "Quiet", "SpA", "Spe"},
+		{Calm, "Calm", "SpD", "Atk"},
+		{Careful, "Careful", "SpD", "SpA"},
+		{Serious, "Serious", "", ""},
+		{Quirky, "Quirky", "", ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.name, tt.nature.String())
+		assert.Equal(t, tt.boosted, tt.nature.Boosted(), tt.name)
+		assert.Equal(t, tt.hindered, tt.nature.Hindered(), tt.name)
+	}
+	assert.Equal(t, 1.1, Adamant.Multiplier("Attack"))
+	assert.Equal(t, 0.9, Adamant.Multiplier("spa"))
+	assert.Equal(t, 1.0, Adamant.Multiplier("HP"))
+	assert.Equal(t, 1.0, Adamant.Multiplier("Luck"))
+	assert.Equal(t, 1.0, Hardy.Multiplier("Atk"))
+
+	for _, n := range []Nature{0, Quirky + 1, 255} {
+		assert.False(t, n.Valid())
+		assert.False(t, n.Neutral())
+		assert.Empty(t, n.Boosted())
+		assert.Equal(t, 1.0, n.Multiplier("Atk"))
+	}
+	assert.Empty(t, Nature(0).String())
+	assert.Equal(t, "Nature(26)", Nature(26).String())
+
+	for _, name := range []string{"bold", "BOLD", " Bold "} {
+		n, err := ParseNature(name)
+		assert.NoError(t, err)
+		assert.Equal(t, Bold, n)
+	}
+	for _, name := range []string{"", "Bald", "Bold Nature", "大胆"} {
+		_, err := ParseNature(name)
+		assert.Error(t, err, name)
+	}
+}
+
+func TestNature_MarshalText(t *testing.T) {
+	t.Parallel()
+	b, err := json.Marshal(map[string]Nature{"nature": Jolly, "none": 0})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"nature":"Jolly","none":""}`, string(b))
+	_, err = json.Marshal(Nature(26))
+	assert.Error(t, err)
+
+	var v struct {
+		Nature Nature `json:"nature"`
+		None   Nature `json:"none"`
+	}
+	assert.NoError(t, json.Unmarshal([]byte(`{"nature":"jolly","none":""}`), &v))
+	assert.Equal(t, Jolly, v.Nature)
+	assert.Equal(t, Nature(0), v.None)
+	assert.Error(t, json.Unmarshal([]byte(`{"nature":"Bald"}`), &v))
+
+	var n Nature
+	assert.NoError(t, n.UnmarshalText([]byte("Calm")))
+	assert.Equal(t, Calm, n)
+	text, err := n.MarshalText()
+	assert.NoError(t, err)
+	assert.Equal(t, "Calm", string(text))
+}
+
+func TestPokemon_FromShowdownWithOptions_nature(t *testing.T) {
+	t.Parallel()
+	const set = "Koffing\nAbility: Levitate\nBald Nature\n- Haze"
+	p := &Pokemon{}
+	// lenient parsing keeps the nature
+	warnings, err := p.FromShowdownWithOptions(set, ParseOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "Bald", p.Nature)
+
+	_, err = p.FromShowdownWithOptions(set, ParseOptions{Strict: true})
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, CodeInvalidNature, parseErr.Code)
+	assert.Equal(t, 3, parseErr.Line)
+	assert.EqualError(t, err, "team 0, pokemon 0, line 3, column 1: unknown nature: Bald (did you mean Bold?)")
+
+	// natures in other languages are accepted
+	_, err = p.FromShowdownWithOptions("Koffing\nAbility: Levitate\n大胆 Nature\n- Haze", ParseOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bold", p.Nature)
+}
+
+func FuzzParseNature(f *testing.F) {
+	for _, s := range []string{"Bold", "bold", " Jolly ", "Bald", "", "\xff"} {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		n, err := ParseNature(s)
+		var u Nature
+		if uErr := u.UnmarshalText([]byte(s)); (uErr == nil) != (err == nil || len(s) == 0) {
+			t.Fatalf("ParseNature and UnmarshalText disagree on %q: %v, %v", s, err, uErr)
+		}
+		if err != nil {
+			return
+		}
+		if !n.Valid() {
+			t.Fatalf("invalid nature %d for %q", n, s)
+		}
+		if again, err := ParseNature(n.String()); err != nil || again != n {
+			t.Fatalf("round trip of %q gave %v, %v", s, again, err)
+		}
+	})
+}
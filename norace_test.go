@@ -0,0 +1,6 @@
+//go:build !race
+
+package koffing
+
+// raceEnabled reports whether the tests run with the race detector, under which sync.Pool drops some of its items on purpose.
+const raceEnabled = false
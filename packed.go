@@ -0,0 +1,331 @@
+package koffing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+// packedFields is the number of `|`-separated fields of a packed Pokémon.
+const packedFields = 12
+
+// FromPacked parses a Pokémon in Showdown's packed format and stores the result in the pointer receiver.
+// The packed format is `NICKNAME|SPECIES|ITEM|ABILITY|MOVES|NATURE|EVS|GENDER|IVS|SHINY|LEVEL|HAPPINESS`,
+// optionally followed by `,HIDDENPOWERTYPE,POKEBALL,GIGANTAMAX,DYNAMAXLEVEL,TERATYPE` in the happiness slot,
+// where empty slots take the same defaults as Showdown does.
+// Names are taken from the dex when they are known, so that both the IDs of the client, e.g. "willowisp",
+// and the packed names of the server, e.g. "WillOWisp", give "Will-O-Wisp". The ability may be the slot of an ability
+// of the species like the client writes it, i.e. empty or "0" for the first ability, "1", "H" or "S".
+func (p *Pokemon) FromPacked(s string) error {
+	return p.fromPacked(s, 0)
+}
+
+// fromPacked is FromPacked for a Pokémon of the generation, whose species gives the abilities of the slots.
+// Pokémon have no abilities in gens 1 and 2, where an empty slot stays empty.
+func (p *Pokemon) fromPacked(s string, gen int) error {
+	fields := strings.Split(s, "|")
+	if len(fields) != packedFields {
+		return fmt.Errorf("invalid packed pokemon: expected %d fields, got %d: %s", packedFields, len(fields), s)
+	}
+	// nickname & species
+	if len(fields[1]) > 0 {
+		p.Nickname, p.Name = fields[0], unpackName(dex.KindSpecies, fields[1])
+	} else {
+		p.Nickname, p.Name = "", fields[0]
+	}
+	if len(p.Name) == 0 {
+		return fmt.Errorf("invalid packed pokemon: name is required: %s", s)
+	}
+	p.Item = unpackName(dex.KindItem, fields[2])
+	ability, err := p.unpackAbility(fields[3], gen)
+	if err != nil {
+		return err
+	}
+	p.Ability = ability
+	// moves
+	p.Moves = make([]string, 0, 4)
+	if len(fields[4]) > 0 {
+		for _, move := range strings.Split(fields[4], ",") {
+			p.Moves = append(p.Moves, unpackName(dex.KindMove, move))
+		}
+	}
+	p.Nature = unpackName(dex.KindNature, fields[5])
+	// evs
+	evs, err := unpackStats(fields[6], 0)
+	if err != nil {
+		return fmt.Errorf("invalid packed evs: %w", err)
+	}
+	p.Evs.Hp, p.Evs.Atk, p.Evs.Def, p.Evs.Spa, p.Evs.Spd, p.Evs.Spe = evs[0], evs[1], evs[2], evs[3], evs[4], evs[5]
+	p.Gender = fields[7]
+	// ivs
+	ivs, err := unpackStats(fields[8], 31)
+	if err != nil {
+		return fmt.Errorf("invalid packed ivs: %w", err)
+	}
+	p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe = ivs[0], ivs[1], ivs[2], ivs[3], ivs[4], ivs[5]
+	p.Shiny = len(fields[9]) > 0
+	// level
+	p.Level = 0
+	if len(fields[10]) > 0 {
+		level, err := strconv.Atoi(fields[10])
+		if err != nil {
+			return fmt.Errorf("invalid level: %w", err)
+		}
+		p.Level = level
+	}
+	// happiness is followed by optional comma-separated extras
+	misc := strings.Split(fields[11], ",")
+	if len(misc) > 6 {
+		return fmt.Errorf("invalid packed pokemon: too many extras: %s", fields[11])
+	}
+	misc = append(misc, make([]string, 6-len(misc))...)
+	p.Happiness = 255
+	if len(misc[0]) > 0 {
+		happiness, err := strconv.Atoi(misc[0])
+		if err != nil {
+			return fmt.Errorf("invalid happiness: %w", err)
+		}
+		p.Happiness = happiness
+	}
+	p.HiddenPowerType = misc[1]
+	p.Pokeball = unpackName(dex.KindItem, misc[2])
+	p.Gigantamax = len(misc[3]) > 0
+	p.DynamaxLevel = 10
+	if len(misc[4]) > 0 {
+		dynamaxLevel, err := strconv.Atoi(misc[4])
+		if err != nil {
+			return fmt.Errorf("invalid dynamax level: %w", err)
+		}
+		p.DynamaxLevel = dynamaxLevel
+	}
+	p.TeraType = misc[5]
+	return nil
+}
+
+// ToPacked returns the receiver in Showdown's packed format.
+// Like AppendShowdown, it only checks the values, since the generation is unknown; Team.ToPacked applies the rules of its Format.
+func (p Pokemon) ToPacked() (string, error) {
+	if len(p.Name) == 0 {
+		return "", fmt.Errorf("name is required")
+	}
+	if err := p.validateValues(255); err != nil {
+		return "", err
+	}
+	return p.pack(), nil
+}
+
+// pack returns the receiver in Showdown's packed format, leaving the validation to the caller.
+func (p Pokemon) pack() string {
+	var packed strings.Builder
+	packed.Grow(150) // estimated string length
+	// nickname & species
+	name := p.Name
+	if len(p.Nickname) > 0 {
+		name = p.Nickname
+	}
+	packed.WriteString(name)
+	packed.WriteByte('|')
+	if id := packName(p.Name); packName(name) != id {
+		packed.WriteString(id)
+	}
+	packed.WriteByte('|')
+	packed.WriteString(packName(p.Item))
+	packed.WriteByte('|')
+	packed.WriteString(packName(p.Ability))
+	packed.WriteByte('|')
+	// moves
+	for i, move := range p.Moves {
+		if i > 0 {
+			packed.WriteByte(',')
+		}
+		packed.WriteString(packName(move))
+	}
+	packed.WriteByte('|')
+	packed.WriteString(p.Nature)
+	packed.WriteByte('|')
+	packed.WriteString(packStats([6]int{p.Evs.Hp, p.Evs.Atk, p.Evs.Def, p.Evs.Spa, p.Evs.Spd, p.Evs.Spe}, 0))
+	packed.WriteByte('|')
+	packed.WriteString(p.Gender)
+	packed.WriteByte('|')
+	packed.WriteString(packStats([6]int{p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe}, 31))
+	packed.WriteByte('|')
+	if p.Shiny {
+		packed.WriteByte('S')
+	}
+	packed.WriteByte('|')
+	if p.Level > 0 && p.Level != 100 {
+		packed.WriteString(strconv.Itoa(p.Level))
+	}
+	packed.WriteByte('|')
+	if p.Happiness != 255 {
+		packed.WriteString(strconv.Itoa(p.Happiness))
+	}
+	// extras
+	hasDynamaxLevel := p.DynamaxLevel > 0 && p.DynamaxLevel != 10
+	if len(p.Pokeball) > 0 || len(p.HiddenPowerType) > 0 || p.Gigantamax || hasDynamaxLevel || len(p.TeraType) > 0 {
+		packed.WriteByte(',')
+		packed.WriteString(p.HiddenPowerType)
+		packed.WriteByte(',')
+		packed.WriteString(packName(p.Pokeball))
+		packed.WriteByte(',')
+		if p.Gigantamax {
+			packed.WriteByte('G')
+		}
+		packed.WriteByte(',')
+		if hasDynamaxLevel {
+			packed.WriteString(strconv.Itoa(p.DynamaxLevel))
+		}
+		packed.WriteByte(',')
+		packed.WriteString(p.TeraType)
+	}
+	return packed.String()
+}
+
+// FromPacked parses a team in Showdown's packed format, i.e. packed Pokémon separated by `]`,
+// and stores the members in the pointer receiver. Name, Format and Folder are left untouched,
+// and the abilities of the slots are the ones of the generation of the Format.
+func (t *Team) FromPacked(s string) error {
+	t.Pokemon = make([]Pokemon, 0, 6)
+	if len(s) == 0 {
+		return nil
+	}
+	for i, set := range strings.Split(s, "]") {
+		var p Pokemon
+		if err := p.fromPacked(set, t.Gen()); err != nil {
+			return fmt.Errorf("failed to import the packed text to a Pokemon: index: %d, error: %w", i, err)
+		}
+		t.Pokemon = append(t.Pokemon, p)
+	}
+	return nil
+}
+
+// ToPacked returns the members of the receiver in Showdown's packed format.
+// Each member is validated with the rules of the generation of the Format, see Pokemon.ValidateGen.
+func (t Team) ToPacked() (string, error) {
+	var packed strings.Builder
+	gen := t.Gen()
+	for i, pokemon := range t.Pokemon {
+		if err := pokemon.ValidateGen(gen); err != nil {
+			return "", fmt.Errorf("failed to export a Pokemon to packed text: index: %d, error: %w", i, err)
+		}
+		if i > 0 {
+			packed.WriteByte(']')
+		}
+		packed.WriteString(pokemon.pack())
+	}
+	return packed.String(), nil
+}
+
+// packName strips every character but ASCII letters and digits, e.g. "Will-O-Wisp" -> "WillOWisp".
+func packName(name string) string {
+	var packed strings.Builder
+	packed.Grow(len(name))
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' {
+			packed.WriteByte(c)
+		}
+	}
+	return packed.String()
+}
+
+// unpackName returns the name of the dex of a packed name of the kind, e.g. "WillOWisp" or "willowisp" -> "Will-O-Wisp",
+// or splits an unknown name into words with unpackWords.
+func unpackName(kind dex.Kind, name string) string {
+	if len(name) == 0 {
+		return ""
+	}
+	if entry, err := dex.Resolve(kind, name); err == nil {
+		return entry.Name
+	}
+	return unpackWords(name)
+}
+
+// unpackWords reverts packName as well as possible by splitting words on capital letters and numbers,
+// e.g. "LifeOrb" -> "Life Orb". Punctuation removed by packName cannot be recovered.
+func unpackWords(name string) string {
+	var unpacked strings.Builder
+	unpacked.Grow(len(name) * 2)
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		isDigit := c >= '0' && c <= '9'
+		switch {
+		case isDigit && (i == 0 || name[i-1] < '0' || name[i-1] > '9'):
+			unpacked.WriteByte(' ')
+		case !isDigit && i > 0 && name[i-1] >= '0' && name[i-1] <= '9':
+			unpacked.WriteByte(' ')
+		case c >= 'A' && c <= 'Z':
+			unpacked.WriteByte(' ')
+		}
+		unpacked.WriteByte(c)
+	}
+	return strings.Join(strings.Fields(unpacked.String()), " ")
+}
+
+// unpackAbility returns the ability of a packed ability field like Showdown's unpackTeam:
+// the slots "", "0", "1", "H" and "S" are the abilities of the species in the generation, and other values are names.
+func (p Pokemon) unpackAbility(ability string, gen int) (string, error) {
+	switch ability {
+	case "", "0", "1", "H", "S":
+	default:
+		return unpackName(dex.KindAbility, ability), nil
+	}
+	if len(ability) == 0 && (gen == 1 || gen == 2) {
+		return "", nil
+	}
+	species, ok := p.SpeciesGen(gen)
+	if !ok {
+		if len(ability) == 0 {
+			return "", nil
+		}
+		return "", fmt.Errorf("invalid packed ability: slot %s of unknown species %s", ability, p.Name)
+	}
+	slots := map[string]string{"": species.Abilities.Primary, "0": species.Abilities.Primary, "1": species.Abilities.Secondary,
+		"H": species.Abilities.Hidden, "S": species.Abilities.Special}
+	if len(slots[ability]) == 0 && len(ability) > 0 {
+		return "", fmt.Errorf("invalid packed ability: %s has no ability in slot %s", species.Name, ability)
+	}
+	return slots[ability], nil
+}
+
+// unpackStats parses a `,`-separated packed stat spread in the order of HP, Atk, Def, SpA, SpD and Spe.
+// Empty values are replaced with the default value.
+func unpackStats(s string, defaultValue int) ([6]int, error) {
+	stats := [6]int{defaultValue, defaultValue, defaultValue, defaultValue, defaultValue, defaultValue}
+	if len(s) == 0 {
+		return stats, nil
+	}
+	values := strings.Split(s, ",")
+	if len(values) > len(stats) {
+		return stats, fmt.Errorf("too many stats: %s", s)
+	}
+	for i, value := range values {
+		if len(value) == 0 {
+			continue
+		}
+		num, err := strconv.Atoi(value)
+		if err != nil {
+			return stats, err
+		}
+		stats[i] = num
+	}
+	return stats, nil
+}
+
+// packStats returns a `,`-separated packed stat spread, leaving default values empty.
+// The result is empty if all values are default.
+func packStats(stats [6]int, defaultValue int) string {
+	values := make([]string, len(stats))
+	empty := true
+	for i, stat := range stats {
+		if stat != defaultValue {
+			values[i] = strconv.Itoa(stat)
+			empty = false
+		}
+	}
+	if empty {
+		return ""
+	}
+	return strings.Join(values, ",")
+}
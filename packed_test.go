@@ -0,0 +1,241 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+func ExamplePokemon_FromPacked() {
+	p := &Pokemon{}
+	_ = p.FromPacked("Smogon|Koffing|Eviolite|NeutralizingGas|WillOWisp,PainSplit,SludgeBomb,FireBlast|Bold|36,,236,,236,|F|,30,,,30,|S|5|")
+	fmt.Printf("%+v", p)
+	// Output: &{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Neutralizing Gas Level:5 Shiny:true Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:31 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]}
+}
+
+func TestPokemon_FromPacked(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    Pokemon
+		wantErr bool
+	}{
+		{
+			name: "nickname",
+			s:    "Smogon|Koffing|Eviolite|Levitate|PainSplit|Bold|36,,236,,236,|F|,30,,,30,|S|5|",
+			want: Pokemon{Name: "Koffing", Nickname: "Smogon", Gender: "F", Item: "Eviolite", Ability: "Levitate", Level: 5, Shiny: true, Happiness: 255, DynamaxLevel: 10, Nature: "Bold",
+				Evs: struct {
+					Hp  int `json:"hp"`
+					Atk int `json:"atk"`
+					Def int `json:"def"`
+					Spa int `json:"spa"`
+					Spd int `json:"spd"`
+					Spe int `json:"spe"`
+				}{Hp: 36, Def: 236, Spd: 236},
+				Ivs: struct {
+					Hp  int `json:"hp"`
+					Atk int `json:"atk"`
+					Def int `json:"def"`
+					Spa int `json:"spa"`
+					Spd int `json:"spd"`
+					Spe int `json:"spe"`
+				}{Hp: 31, Atk: 30, Def: 31, Spa: 31, Spd: 30, Spe: 31},
+				Moves: []string{"Pain Split"},
+			},
+		},
+		{
+			name: "empty slots",
+			s:    "Venusaur-Gmax||||FrenzyPlant|||||||",
+			want: Pokemon{Name: "Venusaur-Gmax", Ability: "Overgrow", Happiness: 255, DynamaxLevel: 10,
+				Ivs: struct {
+					Hp  int `json:"hp"`
+					Atk int `json:"atk"`
+					Def int `json:"def"`
+					Spa int `json:"spa"`
+					Spd int `json:"spd"`
+					Spe int `json:"spe"`
+				}{Hp: 31, Atk: 31, Def: 31, Spa: 31, Spd: 31, Spe: 31},
+				Moves: []string{"Frenzy Plant"},
+			},
+		},
+		{
+			name: "happiness with extras",
+			s:    "Koffing||||Frustration|||||||0,Fire,PokeBall,G,5,Water",
+			want: Pokemon{Name: "Koffing", Ability: "Levitate", Happiness: 0, HiddenPowerType: "Fire", Pokeball: "Poke Ball", Gigantamax: true, DynamaxLevel: 5, TeraType: "Water",
+				Ivs: struct {
+					Hp  int `json:"hp"`
+					Atk int `json:"atk"`
+					Def int `json:"def"`
+					Spa int `json:"spa"`
+					Spd int `json:"spd"`
+					Spe int `json:"spe"`
+				}{Hp: 31, Atk: 31, Def: 31, Spa: 31, Spd: 31, Spe: 31},
+				Moves: []string{"Frustration"},
+			},
+		},
+		{
+			name: "client IDs and ability slot",
+			s:    "Charizard-Gmax||wacanberry|H|blastburn,willowisp|Timid|||||50|",
+			want: Pokemon{Name: "Charizard-Gmax", Item: "Wacan Berry", Ability: "Solar Power", Level: 50, Happiness: 255, DynamaxLevel: 10, Nature: "Timid",
+				Ivs: struct {
+					Hp  int `json:"hp"`
+					Atk int `json:"atk"`
+					Def int `json:"def"`
+					Spa int `json:"spa"`
+					Spd int `json:"spd"`
+					Spe int `json:"spe"`
+				}{Hp: 31, Atk: 31, Def: 31, Spa: 31, Spd: 31, Spe: 31},
+				Moves: []string{"Blast Burn", "Will-O-Wisp"},
+			},
+		},
+		{name: "too few fields", s: "Koffing|||", wantErr: true},
+		{name: "missing ability slot", s: "Koffing|||S|Haze|||||||", wantErr: true},
+		{name: "slot of an unknown species", s: "Fakemon|||H|Haze|||||||", wantErr: true},
+		{name: "no name", s: "|||||||||||", wantErr: true},
+		{name: "invalid evs", s: "Koffing||||Haze||x,,,,,|||||", wantErr: true},
+		{name: "too many ivs", s: "Koffing||||Haze||||1,1,1,1,1,1,1|||", wantErr: true},
+		{name: "invalid level", s: "Koffing||||Haze||||||x|", wantErr: true},
+		{name: "invalid happiness", s: "Koffing||||Haze|||||||x", wantErr: true},
+		{name: "invalid dynamax level", s: "Koffing||||Haze|||||||,,,,x", wantErr: true},
+		{name: "too many extras", s: "Koffing||||Haze|||||||,,,,,,", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Pokemon{}
+			err := p.FromPacked(tt.s)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, *p)
+			}
+		})
+	}
+}
+
+func ExamplePokemon_ToPacked() {
+	p := Pokemon{Name: "Koffing", Nickname: "Smogon", Ability: "Neutralizing Gas", Level: 50, Happiness: 255, Nature: "Bold", Moves: []string{"Will-O-Wisp", "Haze"}}
+	p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe = 31, 0, 31, 31, 31, 31
+	s, _ := p.ToPacked()
+	fmt.Print(s)
+	// Output: Smogon|Koffing||NeutralizingGas|WillOWisp,Haze|Bold|||,0,,,,||50|
+}
+
+func TestPokemon_ToPacked(t *testing.T) {
+	t.Parallel()
+	p := &Pokemon{}
+	_, err := p.ToPacked()
+	assert.Error(t, err)
+
+	s := "Smogon|Koffing|Eviolite|Levitate|PainSplit,SludgeBomb|Bold|36,,236,,236,|F|,30,,,30,|S|5|"
+	assert.NoError(t, p.FromPacked(s))
+	packed, err := p.ToPacked()
+	assert.NoError(t, err)
+	assert.Equal(t, s, packed)
+
+	// a nickname equal to the species is not packed twice
+	p.Nickname = "Koffing"
+	p.Happiness = 0
+	p.Level = 100
+	packed, err = p.ToPacked()
+	assert.NoError(t, err)
+	assert.Equal(t, "Koffing||Eviolite|Levitate|PainSplit,SludgeBomb|Bold|36,,236,,236,|F|,30,,,30,|S||0", packed)
+
+	p.Pokeball, p.Gigantamax, p.DynamaxLevel, p.TeraType = "Poke Ball", true, 0, "Water"
+	packed, err = p.ToPacked()
+	assert.NoError(t, err)
+	assert.Equal(t, "Koffing||Eviolite|Levitate|PainSplit,SludgeBomb|Bold|36,,236,,236,|F|,30,,,30,|S||0,,PokeBall,G,,Water", packed)
+}
+
+func TestTeam_FromPacked(t *testing.T) {
+	t.Parallel()
+	team := &Team{Name: "Test", Format: "gen8"}
+	assert.NoError(t, team.FromPacked(""))
+	assert.Len(t, team.Pokemon, 0)
+	assert.NoError(t, team.FromPacked("Koffing||Eviolite|Levitate|Haze|Bold||||||]Weezing||BlackSludge|Levitate|Haze|Bold||||||"))
+	assert.Equal(t, "Test", team.Name)
+	assert.Len(t, team.Pokemon, 2)
+	assert.Equal(t, "Black Sludge", team.Pokemon[1].Item)
+	assert.Error(t, team.FromPacked("Koffing||Eviolite|Levitate|Haze|Bold||||||]Weezing"))
+}
+
+func TestTeam_ToPacked(t *testing.T) {
+	t.Parallel()
+	s := "Koffing||Eviolite|Levitate|Haze|Bold||||||]Weezing||BlackSludge|Levitate|Haze|Bold||||||"
+	team := &Team{}
+	assert.NoError(t, team.FromPacked(s))
+	packed, err := team.ToPacked()
+	assert.NoError(t, err)
+	assert.Equal(t, s, packed)
+
+	team.Pokemon[1].Moves = nil
+	_, err = team.ToPacked()
+	assert.Error(t, err)
+
+	// the members are validated with the rules of the generation of the format
+	team = &Team{Format: "gen1ou"}
+	assert.NoError(t, team.FromPacked("Tauros|||||||||||]Chansey||||SeismicToss||,,,252,252,|||||"))
+	assert.Empty(t, team.Pokemon[1].Ability)
+	_, err = team.ToPacked()
+	assert.Error(t, err)
+	team.Pokemon[0].Moves = []string{"Body Slam"}
+	assert.NoError(t, team.Validate())
+	packed, err = team.ToPacked()
+	assert.NoError(t, err)
+	assert.Equal(t, "Tauros||||BodySlam|||||||]Chansey||||SeismicToss||,,,252,252,|||||", packed)
+	team.Format = "gen3ou"
+	_, err = team.ToPacked()
+	assert.Error(t, err)
+}
+
+func Test_unpackWords(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"":                "",
+		"LifeOrb":         "Life Orb",
+		"NeutralizingGas": "Neutralizing Gas",
+		"WillOWisp":       "Will O Wisp",
+		"Porygon2":        "Porygon 2",
+		"PorygonZ":        "Porygon Z",
+		"leftovers":       "leftovers",
+	}
+	for packed, want := range tests {
+		assert.Equal(t, want, unpackWords(packed), packed)
+	}
+	assert.Equal(t, "WillOWisp", packName("Will-O-Wisp"))
+	assert.Equal(t, "Farfetchd", packName("Farfetch’d"))
+}
+
+func Test_unpackName(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		kind   dex.Kind
+		packed string
+		want   string
+	}{
+		{kind: dex.KindSpecies, packed: "MrMime", want: "Mr. Mime"},
+		{kind: dex.KindSpecies, packed: "PorygonZ", want: "Porygon-Z"},
+		{kind: dex.KindSpecies, packed: "HoOh", want: "Ho-Oh"},
+		{kind: dex.KindSpecies, packed: "Sirfetchd", want: "Sirfetch'd"},
+		{kind: dex.KindSpecies, packed: "VenusaurGmax", want: "Venusaur-Gmax"},
+		{kind: dex.KindSpecies, packed: "venusaurgmax", want: "Venusaur-Gmax"},
+		{kind: dex.KindSpecies, packed: "FakeMon", want: "Fake Mon"},
+		{kind: dex.KindMove, packed: "WillOWisp", want: "Will-O-Wisp"},
+		{kind: dex.KindMove, packed: "willowisp", want: "Will-O-Wisp"},
+		{kind: dex.KindItem, packed: "wacanberry", want: "Wacan Berry"},
+		{kind: dex.KindAbility, packed: "NeutralizingGas", want: "Neutralizing Gas"},
+		{kind: dex.KindNature, packed: "timid", want: "Timid"},
+		{kind: dex.KindItem, packed: "", want: ""},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, unpackName(tt.kind, tt.packed), tt.packed)
+	}
+	var p Pokemon
+	assert.NoError(t, p.FromPacked("Mimey|MrMime||Filter|Psychic|Timid||||||"))
+	assert.Equal(t, "Mimey", p.Nickname)
+	assert.Equal(t, "Mr. Mime", p.Name)
+}
@@ -0,0 +1,109 @@
+package koffing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseOptions controls how Showdown text is parsed. The zero value is lenient and has no limits.
+type ParseOptions struct {
+	// Strict rejects lines that cannot be recognized, which are reported as warnings otherwise.
+	Strict bool
+	// MaxInputSize is the maximum size of the input in bytes. Zero means no limit.
+	MaxInputSize int
+	// MaxLines is the maximum number of lines of the input, including blank lines. Zero means no limit.
+	MaxLines int
+	// MaxPokemon is the maximum number of Pokémon in the input. Zero means no limit.
+	MaxPokemon int
+	// Gen is the generation of the sets whose team has no format in its header, e.g. a single Pokémon. Zero means unknown.
+	// In gens 1 and 2, the Special stat of EVs/IVs lines, e.g. "EVs: 252 Spc", sets both SpA and SpD.
+	Gen int
+	// Canonicalize replaces the species, item, ability, nature and move names with their canonical names in the dex,
+	// e.g. "venusaur gmax" with "Venusaur-Gmax" and "Lefties" with "Leftovers". Names missing from the dex are kept,
+	// and those close to a known name, like "Lefovers", are reported as warnings with CodeUnknownName, also in strict mode.
+	Canonicalize bool
+}
+
+// parser holds the state of parsing Showdown text with ParseOptions.
+type parser struct {
+	opts         ParseOptions
+	warnings     []*ParseError
+	teamIndex    int
+	pokemonIndex int
+	pokemonCount int
+	// gen is the generation of the current team, or 0 if unknown
+	gen int
+}
+
+func newParser(opts ParseOptions) *parser {
+	return &parser{opts: opts, gen: opts.Gen}
+}
+
+// setFormat sets the generation of the current team from its format, falling back to the generation of the options.
+func (ps *parser) setFormat(format string) {
+	ps.gen = formatGen(format)
+	if ps.gen == 0 {
+		ps.gen = ps.opts.Gen
+	}
+}
+
+// errorAt returns a ParseError at the given byte offset of line, with the indices of the current team and Pokémon.
+func (ps *parser) errorAt(line sourceLine, offset int, code ErrorCode, err error) *ParseError {
+	return &ParseError{
+		TeamIndex:    ps.teamIndex,
+		PokemonIndex: ps.pokemonIndex,
+		Line:         line.num,
+		Column:       line.col + offset,
+		Source:       line.raw,
+		Code:         code,
+		Err:          err,
+	}
+}
+
+// unknownLine rejects an unrecognized line in strict mode, or records it as a warning otherwise.
+func (ps *parser) unknownLine(line sourceLine) *ParseError {
+	return ps.warn(ps.errorAt(line, 0, CodeUnknownLine, fmt.Errorf("unrecognized line: %s", line.text)))
+}
+
+// warn returns err in strict mode, or records it as a warning otherwise.
+func (ps *parser) warn(err *ParseError) *ParseError {
+	if ps.opts.Strict {
+		return err
+	}
+	ps.warnings = append(ps.warnings, err)
+	return nil
+}
+
+// checkInput verifies the input against the size and line limits.
+func (ps *parser) checkInput(s string) *ParseError {
+	if ps.opts.MaxInputSize > 0 && len(s) > ps.opts.MaxInputSize {
+		err := ps.errorAt(sourceLine{}, 0, CodeLimitExceeded, fmt.Errorf("input size %d exceeds the limit %d", len(s), ps.opts.MaxInputSize))
+		err.PokemonIndex = -1
+		return err
+	}
+	if ps.opts.MaxLines > 0 {
+		if lines := strings.Count(s, "\n") + 1; lines > ps.opts.MaxLines {
+			err := ps.errorAt(sourceLine{num: ps.opts.MaxLines + 1, col: 1}, 0, CodeLimitExceeded, fmt.Errorf("number of lines %d exceeds the limit %d", lines, ps.opts.MaxLines))
+			err.PokemonIndex = -1
+			return err
+		}
+	}
+	return nil
+}
+
+// countPokemon counts a Pokémon starting at line against the limit.
+func (ps *parser) countPokemon(line sourceLine) *ParseError {
+	ps.pokemonCount++
+	if ps.opts.MaxPokemon > 0 && ps.pokemonCount > ps.opts.MaxPokemon {
+		return ps.errorAt(line, 0, CodeLimitExceeded, fmt.Errorf("number of pokemon exceeds the limit %d", ps.opts.MaxPokemon))
+	}
+	return nil
+}
+
+// result returns the warnings and the error, converting a nil *ParseError to a nil error.
+func (ps *parser) result(err *ParseError) ([]*ParseError, error) {
+	if err != nil {
+		return ps.warnings, err
+	}
+	return ps.warnings, nil
+}
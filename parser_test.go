@@ -0,0 +1,106 @@
+package koffing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const typoSet = `Rotom-Wash @ Leftovers
+Abilty: Levitate
+EVs: 252 HP / 4 Def / 252 SpD
+Calm Nature
+EV: 252 Atk
+- Volt Switch
+- Hydro Pump`
+
+func ExamplePokemon_FromShowdownWithOptions() {
+	p := &Pokemon{}
+	warnings, _ := p.FromShowdownWithOptions(typoSet, ParseOptions{})
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+	_, err := p.FromShowdownWithOptions(typoSet, ParseOptions{Strict: true})
+	fmt.Println(err)
+	// Output: team 0, pokemon 0, line 2, column 1: unrecognized line: Abilty: Levitate
+	// team 0, pokemon 0, line 5, column 1: unrecognized line: EV: 252 Atk
+	// team 0, pokemon 0, line 2, column 1: unrecognized line: Abilty: Levitate
+}
+
+func TestPokemon_FromShowdownWithOptions(t *testing.T) {
+	t.Parallel()
+	p := &Pokemon{}
+	warnings, err := p.FromShowdownWithOptions(typoSet, ParseOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, CodeUnknownLine, warnings[1].Code)
+	assert.Equal(t, "EV: 252 Atk", warnings[1].Source)
+	assert.Equal(t, []string{"Volt Switch", "Hydro Pump"}, p.Moves)
+
+	_, err = p.FromShowdownWithOptions(typoSet, ParseOptions{Strict: true})
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, CodeUnknownLine, parseErr.Code)
+	assert.Equal(t, 2, parseErr.Line)
+
+	// a clean set has no warning even in strict mode
+	warnings, err = p.FromShowdownWithOptions(strings.ReplaceAll(strings.ReplaceAll(typoSet, "Abilty", "Ability"), "EV: 252 Atk\n", ""), ParseOptions{Strict: true})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 0)
+
+	_, err = p.FromShowdownWithOptions(typoSet, ParseOptions{MaxPokemon: 1})
+	assert.NoError(t, err)
+}
+
+func TestTeam_FromShowdownWithOptions(t *testing.T) {
+	t.Parallel()
+	s := "=== [gen8] Test ===\n\n" + typoSet + "\n\n" + typoSet
+	team := &Team{}
+	warnings, err := team.FromShowdownWithOptions(s, ParseOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 4)
+	assert.Equal(t, 1, warnings[2].PokemonIndex)
+	assert.Equal(t, 12, warnings[2].Line)
+
+	tests := []struct {
+		name string
+		opts ParseOptions
+		code ErrorCode
+		line int
+	}{
+		{name: "strict", opts: ParseOptions{Strict: true}, code: CodeUnknownLine, line: 4},
+		{name: "max input size", opts: ParseOptions{MaxInputSize: 100}, code: CodeLimitExceeded, line: 0},
+		{name: "max lines", opts: ParseOptions{MaxLines: 10}, code: CodeLimitExceeded, line: 11},
+		{name: "max pokemon", opts: ParseOptions{MaxPokemon: 1}, code: CodeLimitExceeded, line: 11},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := new(Team).FromShowdownWithOptions(s, tt.opts)
+			var parseErr *ParseError
+			assert.True(t, errors.As(err, &parseErr))
+			assert.Equal(t, tt.code, parseErr.Code)
+			assert.Equal(t, tt.line, parseErr.Line)
+		})
+	}
+}
+
+func TestTeamCollection_FromShowdownWithOptions(t *testing.T) {
+	t.Parallel()
+	s := "=== [gen8] A ===\n\n" + typoSet + "\n\n=== [gen8] B ===\n\n" + typoSet
+	c := &TeamCollection{}
+	warnings, err := c.FromShowdownWithOptions(s, ParseOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 4)
+	assert.Equal(t, 1, warnings[3].TeamIndex)
+	assert.Equal(t, 0, warnings[3].PokemonIndex)
+
+	// the Pokémon limit applies to the whole collection
+	_, err = c.FromShowdownWithOptions(s, ParseOptions{MaxPokemon: 1})
+	var parseErr *ParseError
+	assert.True(t, errors.As(err, &parseErr))
+	assert.Equal(t, CodeLimitExceeded, parseErr.Code)
+	assert.Equal(t, 1, parseErr.TeamIndex)
+}
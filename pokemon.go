@@ -2,42 +2,47 @@ package koffing
 
 import (
 	"fmt"
+	"io"
 	"strconv"
 	"strings"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/txfs19260817/koffing-go/dex"
 )
 
 var json = jsoniter.ConfigCompatibleWithStandardLibrary
 
 // Pokemon contains all properties of a Pokémon you can set in Teambuilder on Pokémon Showdown.
+// HiddenPowerType holds the "Hidden Power:" line, which overrides the type derived from IVs since Gen 7.
+// DynamaxLevel defaults to 10 and is treated as unset if 0.
 type Pokemon struct {
-	Name      string `json:"name"`
-	Nickname  string `json:"nickname"`
-	Gender    string `json:"gender"`
-	Item      string `json:"item"`
-	Ability   string `json:"ability"`
-	Level     int    `json:"level"`
-	Shiny     bool   `json:"shiny"`
-	Happiness int    `json:"happiness"`
-	Nature    string `json:"nature"`
-	Evs       struct {
-		Hp  int `json:"hp"`
-		Atk int `json:"atk"`
-		Def int `json:"def"`
-		Spa int `json:"spa"`
-		Spd int `json:"spd"`
-		Spe int `json:"spe"`
-	} `json:"evs"`
-	Ivs struct {
-		Hp  int `json:"hp"`
-		Atk int `json:"atk"`
-		Def int `json:"def"`
-		Spa int `json:"spa"`
-		Spd int `json:"spd"`
-		Spe int `json:"spe"`
-	} `json:"ivs"`
-	Moves []string `json:"moves"`
+	Name            string   `json:"name"`
+	Nickname        string   `json:"nickname"`
+	Gender          string   `json:"gender"`
+	Item            string   `json:"item"`
+	Ability         string   `json:"ability"`
+	Level           int      `json:"level"`
+	Shiny           bool     `json:"shiny"`
+	Happiness       int      `json:"happiness"`
+	Pokeball        string   `json:"pokeball,omitempty"`
+	HiddenPowerType string   `json:"hpType,omitempty"`
+	DynamaxLevel    int      `json:"dynamaxLevel,omitempty"`
+	Gigantamax      bool     `json:"gigantamax,omitempty"`
+	TeraType        string   `json:"teraType,omitempty"`
+	Nature          string   `json:"nature"`
+	Evs             Stats    `json:"evs"`
+	Ivs             Stats    `json:"ivs"`
+	Moves           []string `json:"moves"`
+}
+
+// Stats contains a value for each of the six stats, such as EVs and IVs.
+type Stats struct {
+	Hp  int `json:"hp"`
+	Atk int `json:"atk"`
+	Def int `json:"def"`
+	Spa int `json:"spa"`
+	Spd int `json:"spd"`
+	Spe int `json:"spe"`
 }
 
 // FromJson parses the JSON-encoded Pokémon data and stores the result in the pointer receiver.
@@ -51,221 +56,380 @@ func (p Pokemon) ToJson() (string, error) {
 }
 
 // FromShowdown parses the Showdown-formatted Pokémon data and stores the result in the pointer receiver.
+// Unrecognized lines are ignored. The returned error is a *ParseError if the text cannot be parsed.
 func (p *Pokemon) FromShowdown(s string) error {
-	lines := trimLines(strings.Split(s, "\n"))
-	if len(lines) < 3 {
-		return fmt.Errorf("invalid pokemon input: %s", s)
-	}
-	// name line - name/nickname
-	if nicknameWithNameRegex.MatchString(lines[0]) {
-		submatch := nicknameWithNameRegex.FindStringSubmatch(lines[0])
-		if len(submatch) != 3 {
-			return fmt.Errorf("invalid name with nickname: %s", lines[0])
+	_, err := p.FromShowdownWithOptions(s, ParseOptions{})
+	return err
+}
+
+// FromShowdownWithOptions is like FromShowdown, but parses with opts.
+// It returns the unrecognized lines as warnings unless opts.Strict is set, in which case they are errors.
+func (p *Pokemon) FromShowdownWithOptions(s string, opts ParseOptions) (warnings []*ParseError, err error) {
+	ps := newParser(opts)
+	if err := ps.checkInput(s); err != nil {
+		return ps.result(err)
+	}
+	lines := splitLines(s)
+	if len(lines) > 0 {
+		if err := ps.countPokemon(lines[0]); err != nil {
+			return ps.result(err)
 		}
-		p.Nickname, p.Name = submatch[1], submatch[2]
-	} else if nameRegex.MatchString(lines[0]) {
-		p.Name = strings.TrimSpace(nameRegex.FindString(lines[0]))
-	} else {
-		return fmt.Errorf("invalid name: %s", lines[0])
 	}
-	// name line - gender
-	if genderRegex.MatchString(lines[0]) {
-		p.Gender = string(genderRegex.FindString(lines[0])[1])
+	return ps.result(p.fromLines(ps, lines))
+}
+
+// fromLines parses the non-blank lines of a Pokémon set.
+func (p *Pokemon) fromLines(ps *parser, lines []sourceLine) *ParseError {
+	if len(lines) < 3 {
+		line := sourceLine{num: 1, col: 1}
+		if len(lines) > 0 {
+			line = lines[0]
+		}
+		return ps.errorAt(line, 0, CodeTooFewLines, fmt.Errorf("invalid pokemon input: expected at least 3 lines, got %d", len(lines)))
 	}
-	// name line - item
-	if itemRegex.MatchString(lines[0]) {
-		p.Item = itemRegex.FindStringSubmatch(lines[0])[1]
+	// name line - nickname, species, gender and item
+	if offset, err := p.parseNameLine(lines[0].text); err != nil {
+		return ps.errorAt(lines[0], offset, CodeInvalidName, err)
 	}
+	ps.canonicalNameLine(p, lines[0])
 	// init with some default values
-	p.Happiness = 255
+	p.setDefaults()
 	p.Moves = make([]string, 0, 4)
-	p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe = 31, 31, 31, 31, 31, 31
 	// other lines
-	for _, line := range lines[1:] {
-		switch {
-		case abilityRegex.MatchString(line):
-			p.Ability = abilityRegex.FindStringSubmatch(line)[1]
-		case levelRegex.MatchString(line):
-			level, err := strconv.Atoi(levelRegex.FindStringSubmatch(line)[1])
+	for _, l := range lines[1:] {
+		line := l.text
+		kind, v := scanLine(line)
+		if kind == LineUnknown {
+			// labels in other languages, e.g. "特性: 威吓"
+			if english, ok := delocalizeLine(line); ok {
+				line = english
+				kind, v = scanLine(line)
+			}
+		}
+		value := v.of(line)
+		switch kind {
+		case LineAbility:
+			p.Ability = ps.canonicalName(l, v.start, dex.KindAbility, value)
+		case LineLevel:
+			level, err := strconv.Atoi(value)
 			if err != nil {
-				return fmt.Errorf("invalid level: %w", err)
+				return ps.errorAt(l, v.start, CodeInvalidLevel, fmt.Errorf("invalid level: %w", err))
 			}
 			p.Level = level
-		case shinyRegex.MatchString(line):
-			p.Shiny = strings.ToLower(shinyRegex.FindStringSubmatch(line)[1]) == "yes"
-		case happinessRegex.MatchString(line):
-			happiness, err := strconv.Atoi(happinessRegex.FindStringSubmatch(line)[1])
+		case LineShiny:
+			p.Shiny = strings.EqualFold(value, "yes")
+		case LineHappiness:
+			happiness, err := strconv.Atoi(value)
 			if err != nil {
-				return fmt.Errorf("invalid happiness: %w", err)
+				return ps.errorAt(l, v.start, CodeInvalidHappiness, fmt.Errorf("invalid happiness: %w", err))
 			}
 			p.Happiness = happiness
-		case natureRegex.MatchString(line):
-			p.Nature = natureRegex.FindStringSubmatch(line)[1]
-		case eivsRegex.MatchString(line):
-			m, prop, err := fromEIvsLineToMap(line)
+		case LinePokeball:
+			p.Pokeball = value
+		case LineHiddenPower:
+			p.HiddenPowerType = value
+		case LineDynamaxLevel:
+			dynamaxLevel, err := strconv.Atoi(value)
+			if err != nil {
+				return ps.errorAt(l, v.start, CodeInvalidDynamaxLevel, fmt.Errorf("invalid dynamax level: %w", err))
+			}
+			p.DynamaxLevel = dynamaxLevel
+		case LineGigantamax:
+			p.Gigantamax = strings.EqualFold(value, "yes")
+		case LineTeraType:
+			p.TeraType = value
+		case LineNature:
+			nature := ps.canonicalName(l, v.start, dex.KindNature, value)
+			if ps.opts.Strict {
+				// natures in other languages are translated below
+				english, _ := translateName(natureNames, nature, "", English)
+				if _, err := ParseNature(english); err != nil {
+					return ps.errorAt(l, v.start, CodeInvalidNature, err)
+				}
+			}
+			p.Nature = nature
+		case LineEVs:
+			evs, offset, err := parseStatsLine(value, 0, ps.gen)
 			if err != nil {
-				return fmt.Errorf("error in parsing evs/ivs line: %w", err)
+				return ps.errorAt(l, v.start+offset, CodeInvalidStats, fmt.Errorf("error in parsing evs/ivs line: %w", err))
 			}
-			if strings.Contains(prop, "E") {
-				p.Evs.Hp = m["HP"]
-				p.Evs.Atk = m["Atk"]
-				p.Evs.Def = m["Def"]
-				p.Evs.Spa = m["SpA"]
-				p.Evs.Spd = m["SpD"]
-				p.Evs.Spe = m["Spe"]
-			} else if strings.Contains(prop, "I") {
-				p.Ivs.Hp = m["HP"]
-				p.Ivs.Atk = m["Atk"]
-				p.Ivs.Def = m["Def"]
-				p.Ivs.Spa = m["SpA"]
-				p.Ivs.Spd = m["SpD"]
-				p.Ivs.Spe = m["Spe"]
-			} else {
-				return fmt.Errorf("error in parsing evs/ivs line: invalid prop %s", prop)
+			p.Evs = evs
+		case LineIVs:
+			ivs, offset, err := parseStatsLine(value, 31, ps.gen)
+			if err != nil {
+				return ps.errorAt(l, v.start+offset, CodeInvalidStats, fmt.Errorf("error in parsing evs/ivs line: %w", err))
+			}
+			p.Ivs = ivs
+		case LineMove:
+			p.Moves = append(p.Moves, ps.canonicalName(l, v.start, dex.KindMove, value))
+		default:
+			if err := ps.unknownLine(l); err != nil {
+				return err
 			}
-		case moveRegex.MatchString(line):
-			p.Moves = append(p.Moves, moveRegex.FindStringSubmatch(line)[1])
 		}
 	}
+	// names in other languages, e.g. "瓦斯弹"
+	p.translateNames("", English)
 	return nil
 }
 
-func fromEIvsLineToMap(line string) (eivsMap map[string]int, prop string, err error) {
-	segments := eivsRegex.FindStringSubmatch(line)
-	if len(segments) != 3 {
-		return nil, "", fmt.Errorf("invalid evs/ivs line: %s", line)
-	}
-	prop = segments[1]
-	if strings.Contains(prop, "I") {
-		eivsMap = map[string]int{"HP": 31, "Atk": 31, "Def": 31, "SpA": 31, "SpD": 31, "Spe": 31}
-	} else {
-		eivsMap = map[string]int{"HP": 0, "Atk": 0, "Def": 0, "SpA": 0, "SpD": 0, "Spe": 0}
-	}
-	parts := strings.Split(segments[2], " / ")
-	for _, part := range parts {
-		stat := strings.Split(part, " ")
-		num, err := strconv.Atoi(stat[0])
-		if err != nil {
-			return nil, "", err
+// statLabels maps the IDs of the stat names accepted by Showdown to the labels used in EVs/IVs lines.
+var statLabels = map[string]string{
+	"hp": "HP", "hitpoints": "HP",
+	"atk": "Atk", "attack": "Atk",
+	"def": "Def", "defense": "Def",
+	"spa": "SpA", "spatk": "SpA", "spattack": "SpA", "specialattack": "SpA", "specialatk": "SpA", "special": "SpA", "spc": "SpA",
+	"spd": "SpD", "spdef": "SpD", "spdefense": "SpD", "specialdefense": "SpD", "specialdef": "SpD",
+	"spe": "Spe", "speed": "Spe",
+}
+
+// statLabel returns the label of a stat name like "Sp. Atk", "Speed" or "攻击", or false if the name is unknown.
+func statLabel(name string) (string, bool) {
+	// Showdown reads "Spd" as Speed, while "SpD" is Special Defense
+	if name == "Spd" {
+		return "Spe", true
+	}
+	// the ID is built in a buffer longer than any known ID, so that the lookup does not allocate
+	var buf [16]byte
+	id := buf[:0]
+	for i := 0; i < len(name) && len(id) < len(buf); i++ {
+		switch c := name[i]; {
+		case c >= 'a' && c <= 'z' || c >= '0' && c <= '9':
+			id = append(id, c)
+		case c >= 'A' && c <= 'Z':
+			id = append(id, c+'a'-'A')
 		}
-		eivsMap[stat[1]] = num
 	}
-	return eivsMap, segments[1], nil
+	if label, ok := statLabels[string(id)]; ok {
+		return label, true
+	}
+	return localizedStatLabel(name)
+}
+
+// setDefaults sets the fields which Showdown text may omit to the defaults of the Teambuilder, whatever the format,
+// except Level which is kept unset. The defaults of a format are applied by Effective.
+func (p *Pokemon) setDefaults() {
+	p.Happiness = 255
+	p.DynamaxLevel = 10
+	p.Evs = Stats{}
+	p.Ivs = Stats{Hp: 31, Atk: 31, Def: 31, Spa: 31, Spd: 31, Spe: 31}
 }
 
-// ToShowdown returns the Showdown-formatted text of the receiver.
+// ToShowdown returns the Showdown-formatted text of the receiver, byte for byte as Teams.export on Pokémon Showdown writes it:
+// every line ends with two spaces, and the lines holding default values, e.g. level 100 or happiness 255, are omitted.
+// Ability and nature are optional, since Pokémon have neither in gens 1 and 2.
 func (p Pokemon) ToShowdown() (string, error) {
-	var showdown strings.Builder
-	showdown.Grow(300) // estimated string length
-	if err := p.Validate(); err != nil {
+	return p.ToShowdownWithOptions(ShowdownOptions{})
+}
+
+// ShowdownOptions controls how Showdown text is written. The zero value writes English text like Showdown does.
+type ShowdownOptions struct {
+	// Language is the language of the labels and names, e.g. Japanese writes "特性: いかく" for "Ability: Intimidate".
+	// Names missing from the translation tables are written as is, and Translate lists them. Empty means English.
+	Language Language
+	// Gen is the generation of the Pokémon. Zero means unknown, or the generation of the format for a Team.
+	Gen int
+	// Spc writes equal SpA and SpD as the shared Special stat in gens 1 and 2, e.g. "EVs: 252 Spc".
+	// Teams.export of Showdown always writes SpA and SpD, but FromShowdown reads both.
+	Spc bool
+}
+
+// ToShowdownWithOptions is like ToShowdown, but writes with opts. Localized text can be read back by FromShowdown.
+func (p Pokemon) ToShowdownWithOptions(opts ShowdownOptions) (string, error) {
+	showdown, err := p.appendShowdown(make([]byte, 0, 300), opts) // estimated string length
+	if err != nil {
 		return "", err
 	}
+	return string(showdown), nil
+}
+
+// AppendShowdown appends the Showdown-formatted text of the receiver to dst like ToShowdown writes it, and returns the extended buffer.
+// On failure, dst is returned unchanged. Reusing the buffer avoids allocating for every Pokémon in bulk exports.
+func (p Pokemon) AppendShowdown(dst []byte) ([]byte, error) {
+	return p.appendShowdown(dst, ShowdownOptions{})
+}
+
+// WriteTo implements io.WriterTo by writing the Showdown-formatted text of the receiver like ToShowdown writes it.
+func (p Pokemon) WriteTo(w io.Writer) (int64, error) {
+	return writeBuffered(w, func(dst []byte) ([]byte, error) {
+		return p.appendShowdown(dst, ShowdownOptions{})
+	})
+}
+
+func (p Pokemon) appendShowdown(dst []byte, opts ShowdownOptions) ([]byte, error) {
+	if len(p.Name) == 0 {
+		return dst, fmt.Errorf("name is required")
+	}
+	if err := p.validateValues(255); err != nil {
+		return dst, err
+	}
+	lang := opts.Language
+	if len(lang) == 0 {
+		lang = English
+	}
+	labels, err := showdownLabels(lang)
+	if err != nil {
+		return dst, err
+	}
+	label := func(english string) string {
+		if l, ok := labels[english]; ok {
+			return l
+		}
+		return english
+	}
+	// the nickname is compared with the English name, e.g. Smogon is the German name of Koffing
+	nicknamed := len(p.Nickname) > 0 && p.Nickname != p.Name
+	statNames := statShortNames
+	if lang != English {
+		p.translateNames(English, lang)
+		for i, stat := range statShortNames {
+			statNames[i] = label(stat)
+		}
+	}
+	showdown := dst
 	// name/nickname
-	if len(p.Nickname) > 0 {
-		showdown.WriteString(p.Nickname)
-		showdown.WriteString(" (")
-		showdown.WriteString(p.Name)
-		showdown.WriteByte(')')
+	if nicknamed {
+		showdown = append(showdown, p.Nickname...)
+		showdown = append(showdown, " ("...)
+		showdown = append(showdown, p.Name...)
+		showdown = append(showdown, ')')
 	} else {
-		showdown.WriteString(p.Name)
+		showdown = append(showdown, p.Name...)
 	}
 	// gender
 	if len(p.Gender) > 0 {
-		showdown.WriteString(" (")
-		showdown.WriteString(strings.ToUpper(p.Gender))
-		showdown.WriteByte(')')
+		showdown = append(showdown, " ("...)
+		showdown = append(showdown, p.Gender...)
+		showdown = append(showdown, ')')
 	}
 	// item
 	if len(p.Item) > 0 {
-		showdown.WriteString(" @ ")
-		showdown.WriteString(p.Item)
+		showdown = append(showdown, " @ "...)
+		showdown = append(showdown, p.Item...)
+	}
+	showdown = append(showdown, eol...)
+	// ability
+	if len(p.Ability) > 0 {
+		showdown = appendLine(showdown, label("Ability"), p.Ability)
 	}
-	showdown.WriteByte('\n')
 	// level
-	if p.Level > 0 {
-		showdown.WriteString("Level: ")
-		showdown.WriteString(strconv.Itoa(p.Level))
-		showdown.WriteByte('\n')
+	if p.Level > 0 && p.Level != 100 {
+		showdown = appendIntLine(showdown, label("Level"), p.Level)
 	}
-	// ability
-	showdown.WriteString("Ability: ")
-	showdown.WriteString(p.Ability)
-	showdown.WriteByte('\n')
 	// shiny
 	if p.Shiny {
-		showdown.WriteString("Shiny: Yes\n")
+		showdown = appendLine(showdown, label("Shiny"), label("Yes"))
 	}
 	// happiness
-	if p.Level > 0 {
-		showdown.WriteString("Happiness: ")
-		showdown.WriteString(strconv.Itoa(p.Happiness))
-		showdown.WriteByte('\n')
-	}
-	// evs
-	evs := make([]string, 0, 6)
-	if p.Evs.Hp != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Hp)+" HP")
+	if p.Happiness != 255 {
+		showdown = appendIntLine(showdown, label("Happiness"), p.Happiness)
 	}
-	if p.Evs.Atk != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Atk)+" Atk")
+	// pokeball
+	if len(p.Pokeball) > 0 {
+		showdown = appendLine(showdown, label("Pokeball"), p.Pokeball)
 	}
-	if p.Evs.Def != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Def)+" Def")
+	// hidden power
+	if len(p.HiddenPowerType) > 0 {
+		showdown = appendLine(showdown, label("Hidden Power"), p.HiddenPowerType)
 	}
-	if p.Evs.Spa != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Spa)+" SpA")
+	// dynamax level
+	if p.DynamaxLevel > 0 && p.DynamaxLevel != 10 {
+		showdown = appendIntLine(showdown, label("Dynamax Level"), p.DynamaxLevel)
 	}
-	if p.Evs.Spd != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Spd)+" SpD")
+	// gigantamax
+	if p.Gigantamax {
+		showdown = appendLine(showdown, label("Gigantamax"), label("Yes"))
 	}
-	if p.Evs.Spe != 0 {
-		evs = append(evs, strconv.Itoa(p.Evs.Spe)+" Spe")
+	// tera type
+	if len(p.TeraType) > 0 {
+		showdown = appendLine(showdown, label("Tera Type"), p.TeraType)
 	}
-	if len(evs) > 0 {
-		showdown.WriteString("EVs: ")
-		showdown.WriteString(strings.Join(evs, " / "))
-		showdown.WriteByte('\n')
+	// evs
+	special := ""
+	if opts.Spc && (opts.Gen == 1 || opts.Gen == 2) {
+		special = label("Spc")
 	}
-
+	showdown = p.Evs.appendLine(showdown, label("EVs"), 0, statNames, special)
 	// nature
-	showdown.WriteString(p.Nature)
-	showdown.WriteString(" Nature\n")
-	// ivs
-	ivs := make([]string, 0, 6)
-	if p.Ivs.Hp != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Hp)+" HP")
-	}
-	if p.Ivs.Atk != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Atk)+" Atk")
-	}
-	if p.Ivs.Def != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Def)+" Def")
-	}
-	if p.Ivs.Spa != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Spa)+" SpA")
-	}
-	if p.Ivs.Spd != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Spd)+" SpD")
-	}
-	if p.Ivs.Spe != 31 {
-		ivs = append(ivs, strconv.Itoa(p.Ivs.Spe)+" Spe")
-	}
-	if len(ivs) > 0 {
-		showdown.WriteString("IVs: ")
-		showdown.WriteString(strings.Join(ivs, " / "))
-		showdown.WriteByte('\n')
+	if len(p.Nature) > 0 {
+		if lang == English {
+			showdown = append(showdown, p.Nature...)
+			showdown = append(showdown, " Nature"+eol...)
+		} else {
+			showdown = appendLine(showdown, label("Nature"), p.Nature)
+		}
 	}
+	// ivs
+	showdown = p.Ivs.appendLine(showdown, label("IVs"), 31, statNames, special)
 	// moves
 	for _, move := range p.Moves {
-		if len(move) > 0 {
-			showdown.WriteString("- ")
-			showdown.WriteString(move)
-			showdown.WriteByte('\n')
+		if len(move) == 0 {
+			continue
+		}
+		showdown = append(showdown, "- "...)
+		if typ, ok := ParseHiddenPower(move); ok && len(typ) > 0 {
+			showdown = append(showdown, "Hidden Power ["...)
+			showdown = append(showdown, typ...)
+			showdown = append(showdown, ']')
+		} else {
+			showdown = append(showdown, move...)
 		}
+		showdown = append(showdown, eol...)
 	}
-	return showdown.String(), nil
+	return showdown, nil
+}
+
+// eol ends every line exported by Showdown.
+const eol = "  \n"
+
+// appendLine appends a line of a set like "Ability: Levitate".
+func appendLine(dst []byte, label, value string) []byte {
+	dst = append(dst, label...)
+	dst = append(dst, ": "...)
+	dst = append(dst, value...)
+	return append(dst, eol...)
 }
 
+// appendIntLine appends a line of a set with a number like "Level: 50".
+func appendIntLine(dst []byte, label string, value int) []byte {
+	dst = append(dst, label...)
+	dst = append(dst, ": "...)
+	dst = strconv.AppendInt(dst, int64(value), 10)
+	return append(dst, eol...)
+}
+
+// appendLine appends an EVs/IVs line like "EVs: 252 HP / 4 Def" with the stats other than defaultValue and the given stat names,
+// or nothing if there is none. If special is not empty, it is the name of the Special stat written for equal SpA and SpD.
+func (s Stats) appendLine(dst []byte, label string, defaultValue int, names [6]string, special string) []byte {
+	n := len(dst)
+	shared := len(special) > 0 && s.Spa == s.Spd
+	for i, v := range [6]int{s.Hp, s.Atk, s.Def, s.Spa, s.Spd, s.Spe} {
+		if v == defaultValue || shared && i == 4 {
+			continue
+		}
+		name := names[i]
+		if shared && i == 3 {
+			name = special
+		}
+		if len(dst) == n {
+			dst = append(dst, label...)
+			dst = append(dst, ": "...)
+		} else {
+			dst = append(dst, " / "...)
+		}
+		dst = strconv.AppendInt(dst, int64(v), 10)
+		dst = append(dst, ' ')
+		dst = append(dst, name...)
+	}
+	if len(dst) == n {
+		return dst
+	}
+	return append(dst, eol...)
+}
+
+// statShortNames are the labels of the stats in EVs/IVs lines, in the order of Stats.
+var statShortNames = [6]string{"HP", "Atk", "Def", "SpA", "SpD", "Spe"}
+
 // Validate verify the legitimation of this Pokemon with some basic rules, most of which are empty and range checking.
 func (p Pokemon) Validate() error {
 	if len(p.Name) == 0 {
@@ -277,26 +441,38 @@ func (p Pokemon) Validate() error {
 	if len(p.Nature) == 0 {
 		return fmt.Errorf("nature is required")
 	}
+	if _, err := ParseNature(p.Nature); err != nil {
+		return err
+	}
+	return p.validateValues(252)
+}
+
+// validateValues checks the ranges of the values and the gender, leaving the required fields to the caller.
+// EVs are in range [0, maxEV].
+func (p Pokemon) validateValues(maxEV int) error {
 	if p.Happiness < 0 || p.Happiness > 255 {
 		return fmt.Errorf("happiness should be in range [0, 255], yours: %d", p.Happiness)
 	}
-	if p.Evs.Hp < 0 || p.Evs.Hp > 252 {
-		return fmt.Errorf("the HP ev should be in range [0, 252], yours: %d", p.Evs.Hp)
+	if p.DynamaxLevel < 0 || p.DynamaxLevel > 10 {
+		return fmt.Errorf("dynamax level should be in range [0, 10], yours: %d", p.DynamaxLevel)
+	}
+	if p.Evs.Hp < 0 || p.Evs.Hp > maxEV {
+		return fmt.Errorf("the HP ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Hp)
 	}
-	if p.Evs.Atk < 0 || p.Evs.Atk > 252 {
-		return fmt.Errorf("the Atk ev should be in range [0, 252], yours: %d", p.Evs.Atk)
+	if p.Evs.Atk < 0 || p.Evs.Atk > maxEV {
+		return fmt.Errorf("the Atk ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Atk)
 	}
-	if p.Evs.Def < 0 || p.Evs.Def > 252 {
-		return fmt.Errorf("the Def ev should be in range [0, 252], yours: %d", p.Evs.Def)
+	if p.Evs.Def < 0 || p.Evs.Def > maxEV {
+		return fmt.Errorf("the Def ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Def)
 	}
-	if p.Evs.Spa < 0 || p.Evs.Spa > 252 {
-		return fmt.Errorf("the Spa ev should be in range [0, 252], yours: %d", p.Evs.Spa)
+	if p.Evs.Spa < 0 || p.Evs.Spa > maxEV {
+		return fmt.Errorf("the Spa ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Spa)
 	}
-	if p.Evs.Spd < 0 || p.Evs.Spd > 252 {
-		return fmt.Errorf("the Spd ev should be in range [0, 252], yours: %d", p.Evs.Spd)
+	if p.Evs.Spd < 0 || p.Evs.Spd > maxEV {
+		return fmt.Errorf("the Spd ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Spd)
 	}
-	if p.Evs.Spe < 0 || p.Evs.Spe > 252 {
-		return fmt.Errorf("the Spe ev should be in range [0, 252], yours: %d", p.Evs.Spe)
+	if p.Evs.Spe < 0 || p.Evs.Spe > maxEV {
+		return fmt.Errorf("the Spe ev should be in range [0, %d], yours: %d", maxEV, p.Evs.Spe)
 	}
 	if p.Ivs.Hp < 0 || p.Ivs.Hp > 31 {
 		return fmt.Errorf("the HP iv should be in range [0, 31], yours: %d", p.Ivs.Hp)
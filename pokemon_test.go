@@ -40,7 +40,7 @@ func ExamplePokemon_FromJson() {
 	}`
 	_ = p.FromJson(paste)
 	fmt.Printf("%+v", p)
-	// Output: &{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Neutralizing Gas Level:5 Shiny:true Happiness:255 Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:0 Spa:31 Spd:30 Spe:0} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]}
+	// Output: &{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Neutralizing Gas Level:5 Shiny:true Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:31 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]}
 }
 
 func TestPokemon_FromJson(t *testing.T) {
@@ -88,7 +88,7 @@ func TestPokemon_FromJson(t *testing.T) {
 	assert.Equal(t, 255, p.Happiness)
 	assert.Equal(t, "Bold", p.Nature)
 	assert.Equal(t, []int{36, 0, 236, 0, 236, 0}, []int{p.Evs.Hp, p.Evs.Atk, p.Evs.Def, p.Evs.Spa, p.Evs.Spd, p.Evs.Spe})
-	assert.Equal(t, []int{31, 30, 0, 31, 30, 31}, []int{p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe})
+	assert.Equal(t, []int{31, 30, 31, 31, 30, 31}, []int{p.Ivs.Hp, p.Ivs.Atk, p.Ivs.Def, p.Ivs.Spa, p.Ivs.Spd, p.Ivs.Spe})
 	assert.Equal(t, []string{"Will-O-Wisp", "Pain Split", "Sludge Bomb", "Fire Blast"}, p.Moves)
 }
 
@@ -185,6 +185,9 @@ func TestPokemon_Validate(t *testing.T) {
 	err = p.Validate()
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "nature is required")
+	p.Nature = "Bald"
+	err = p.Validate()
+	assert.EqualError(t, err, "unknown nature: Bald (did you mean Bold?)")
 	// Happiness
 	p.Nature = "Bold"
 	p.Happiness = -1
@@ -264,7 +267,7 @@ func ExamplePokemon_FromShowdown() {
 	p := &Pokemon{}
 	_ = p.FromShowdown(s)
 	fmt.Printf("%+v", p)
-	// Output: &{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Neutralizing Gas Level:100 Shiny:true Happiness:255 Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:31 Def:31 Spa:31 Spd:30 Spe:0} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]}
+	// Output: &{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Neutralizing Gas Level:100 Shiny:true Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:31 Def:31 Spa:31 Spd:30 Spe:0} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]}
 }
 
 func TestPokemon_FromShowdown(t *testing.T) {
@@ -370,12 +373,11 @@ func ExamplePokemon_ToShowdown() {
 		Moves: []string{"Will-O-Wisp", "Pain Split", "Sludge Bomb", "Fire Blast"},
 	}
 	s, _ := p.ToShowdown()
-	fmt.Print(s)
+	// Showdown ends every line with two spaces, which are trimmed here to fit in this comment
+	fmt.Print(strings.ReplaceAll(s, "  \n", "\n"))
 	// Output: Smogon (Koffing) (F) @ Eviolite
-	//Level: 100
 	//Ability: Neutralizing Gas
 	//Shiny: Yes
-	//Happiness: 255
 	//EVs: 36 HP / 236 Def / 236 SpD
 	//Bold Nature
 	//IVs: 30 Atk / 0 Def / 30 SpD
@@ -416,24 +418,149 @@ func TestPokemon_ToShowdown(t *testing.T) {
 		Moves: []string{"Will-O-Wisp", "Pain Split", "Sludge Bomb", "Fire Blast"},
 	}
 
-	expected := `Smogon (Koffing) (F) @ Eviolite
-				Level: 100
-				Ability: Neutralizing Gas
-				Shiny: Yes
-				Happiness: 255
-				EVs: 36 HP / 236 Def / 236 SpD
-				Bold Nature
-				IVs: 30 Atk / 0 Def / 30 SpD
-				- Will-O-Wisp
-				- Pain Split
-				- Sludge Bomb
-				- Fire Blast
-				`
+	expected := "Smogon (Koffing) (F) @ Eviolite  \n" +
+		"Ability: Neutralizing Gas  \n" +
+		"Shiny: Yes  \n" +
+		"EVs: 36 HP / 236 Def / 236 SpD  \n" +
+		"Bold Nature  \n" +
+		"IVs: 30 Atk / 0 Def / 30 SpD  \n" +
+		"- Will-O-Wisp  \n" +
+		"- Pain Split  \n" +
+		"- Sludge Bomb  \n" +
+		"- Fire Blast  \n"
 	s, err := p.ToShowdown()
 	assert.NoError(t, err)
-	expectedSlice, actualSlice := strings.Split(expected, "\n"), strings.Split(s, "\n")
-	assert.Equal(t, len(expectedSlice), len(actualSlice))
-	for i, e := range expectedSlice {
-		assert.Equal(t, strings.TrimSpace(e), strings.TrimSpace(actualSlice[i]))
+	assert.Equal(t, expected, s)
+
+	// level 100 and happiness 255 are defaults, while other values are written
+	p.Level, p.Happiness, p.Shiny = 50, 0, false
+	s, err = p.ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "Ability: Neutralizing Gas  \nLevel: 50  \nHappiness: 0  \nEVs:")
+
+	// gens 1 and 2 have neither abilities nor natures, and a nickname equal to the species is not written
+	s, err = Pokemon{Name: "Tauros", Nickname: "Tauros", Happiness: 255, Ivs: Stats{30, 30, 30, 30, 30, 30}, Moves: []string{"Body Slam"}}.ToShowdown()
+	assert.NoError(t, err)
+	assert.Equal(t, "Tauros  \nIVs: 30 HP / 30 Atk / 30 Def / 30 SpA / 30 SpD / 30 Spe  \n- Body Slam  \n", s)
+	_, err = Pokemon{Ability: "Levitate", Moves: []string{"Haze"}}.ToShowdown()
+	assert.Error(t, err)
+}
+
+func TestPokemon_ShowdownExtras(t *testing.T) {
+	t.Parallel()
+	s := `Flutter Mane @ Booster Energy
+		Ability: Protosynthesis
+		Level: 50
+		Pokeball: Cherish Ball
+		Hidden Power: Fire
+		Dynamax Level: 5
+		Gigantamax: Yes
+		Tera Type: Fairy
+		EVs: 4 HP / 252 SpA / 252 Spe
+		Timid Nature
+		IVs: 0 Atk
+		- Moonblast
+		- Shadow Ball
+		- Icy Wind
+		- Protect`
+	p := &Pokemon{}
+	assert.NoError(t, p.FromShowdown(s))
+	assert.Equal(t, "Cherish Ball", p.Pokeball)
+	assert.Equal(t, "Fire", p.HiddenPowerType)
+	assert.Equal(t, 5, p.DynamaxLevel)
+	assert.True(t, p.Gigantamax)
+	assert.Equal(t, "Fairy", p.TeraType)
+
+	exported, err := p.ToShowdown()
+	assert.NoError(t, err)
+	for _, line := range []string{"Pokeball: Cherish Ball  \n", "Hidden Power: Fire  \n", "Dynamax Level: 5  \n", "Gigantamax: Yes  \n", "Tera Type: Fairy  \n"} {
+		assert.Contains(t, exported, line)
+	}
+	roundTrip := &Pokemon{}
+	assert.NoError(t, roundTrip.FromShowdown(exported))
+	assert.Equal(t, p, roundTrip)
+
+	j, err := p.ToJson()
+	assert.NoError(t, err)
+	assert.Contains(t, j, `"pokeball":"Cherish Ball","hpType":"Fire","dynamaxLevel":5,"gigantamax":true,"teraType":"Fairy"`)
+	roundTrip = &Pokemon{}
+	assert.NoError(t, roundTrip.FromJson(j))
+	assert.Equal(t, p, roundTrip)
+
+	// defaults are omitted
+	p.DynamaxLevel = 10
+	exported, err = p.ToShowdown()
+	assert.NoError(t, err)
+	assert.NotContains(t, exported, "Dynamax Level")
+	p.DynamaxLevel = 11
+	assert.Error(t, p.Validate())
+}
+
+func Test_parseStatsLine(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line       string
+		gen        int
+		wantStats  Stats
+		wantKind   LineKind
+		wantOffset int
+		wantErr    bool
+	}{
+		{line: "EVs: 252 HP / 4 Def / 252 SpD", wantStats: Stats{Hp: 252, Def: 4, Spd: 252}, wantKind: LineEVs},
+		{line: "EVs: 252 Speed/4 Sp. Atk / 252 Attack", wantStats: Stats{Atk: 252, Spa: 4, Spe: 252}, wantKind: LineEVs},
+		{line: "IVs: 0 Spd / 30 special", wantStats: Stats{Hp: 31, Atk: 31, Def: 31, Spa: 30, Spd: 31, Spe: 0}, wantKind: LineIVs},
+		{line: "IVs: 30 special", gen: 2, wantStats: Stats{Hp: 31, Atk: 31, Def: 31, Spa: 30, Spd: 30, Spe: 31}, wantKind: LineIVs},
+		{line: "EVs: 252 Spc / 252 Spe", gen: 1, wantStats: Stats{Spa: 252, Spd: 252, Spe: 252}, wantKind: LineEVs},
+		{line: "EVs: 252 Spc", gen: 3, wantStats: Stats{Spa: 252}, wantKind: LineEVs},
+		{line: "EVs: 252 SpA / 4 SpD", gen: 2, wantStats: Stats{Spa: 252, Spd: 4}, wantKind: LineEVs},
+		{line: "EVs: ", wantStats: Stats{}, wantKind: LineEVs},
+		{line: "evs:4 hp//  252   Sp.   Def  /", wantStats: Stats{Hp: 4, Spd: 252}, wantKind: LineEVs},
+		{line: "EVs: 252Atk", wantKind: LineEVs, wantOffset: 5, wantErr: true},
+		{line: "EVs: 4 HP / 252 Luck", wantKind: LineEVs, wantOffset: 12, wantErr: true},
+		{line: "IVs: x Atk", wantKind: LineIVs, wantOffset: 5, wantErr: true},
+		{line: "Ability: Levitate", wantKind: LineAbility},
+	}
+	for _, tt := range tests {
+		kind, v := scanLine(tt.line)
+		assert.Equal(t, tt.wantKind, kind, tt.line)
+		if kind != LineEVs && kind != LineIVs {
+			continue
+		}
+		defaultValue := 0
+		if kind == LineIVs {
+			defaultValue = 31
+		}
+		stats, offset, err := parseStatsLine(v.of(tt.line), defaultValue, tt.gen)
+		if tt.wantErr {
+			assert.Error(t, err, tt.line)
+			assert.Equal(t, tt.wantOffset, v.start+offset, tt.line)
+			continue
+		}
+		assert.NoError(t, err, tt.line)
+		assert.Equal(t, tt.wantStats, stats, tt.line)
+	}
+}
+
+func BenchmarkPokemon_FromShowdown(b *testing.B) {
+	s := `Sir Chomps (Garchomp) (M) @ Life Orb  
+Ability: Rough Skin  
+Level: 50  
+Shiny: Yes  
+Tera Type: Steel  
+EVs: 4 HP / 252 Atk / 252 Spe  
+Jolly Nature  
+IVs: 30 SpA  
+- Earthquake  
+- Dragon Claw  
+- Rock Slide  
+- Protect  
+`
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var p Pokemon
+		if err := p.FromShowdown(s); err != nil {
+			b.Fatal(err)
+		}
 	}
 }
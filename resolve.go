@@ -0,0 +1,61 @@
+package koffing
+
+import (
+	"errors"
+
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+// ToID returns the ID of a name like Pokémon Showdown does, so that "venusaur-gmax", "Venusaur-Gmax" and "Venusaur Gmax"
+// all give "venusaurgmax". Use dex.Resolve to get the canonical name of an ID.
+func ToID(name string) string {
+	return dex.ToID(name)
+}
+
+// kindCategories are the categories of the translation tables of the kinds of names.
+var kindCategories = map[dex.Kind]string{
+	dex.KindSpecies: speciesNames,
+	dex.KindItem:    itemNames,
+	dex.KindAbility: abilityNames,
+	dex.KindMove:    moveNames,
+	dex.KindNature:  natureNames,
+}
+
+// canonicalName returns the canonical name of a name of the kind if ParseOptions.Canonicalize is set.
+// Names in other languages are translated to English first. Unknown names are kept as is,
+// and those which are likely misspelled, i.e. have suggestions, are reported at the offset of the line.
+// They are warnings even in strict mode, since a real name may be missing from the dex and only look misspelled.
+func (ps *parser) canonicalName(line sourceLine, offset int, kind dex.Kind, name string) string {
+	if !ps.opts.Canonicalize || len(name) == 0 {
+		return name
+	}
+	if kind == dex.KindMove {
+		// e.g. "hidden power fire", which is written like Showdown does
+		if typ, ok := ParseHiddenPower(name); ok {
+			if len(typ) == 0 {
+				return "Hidden Power"
+			}
+			return "Hidden Power [" + typ + "]"
+		}
+	}
+	english, _ := translateName(kindCategories[kind], name, "", English)
+	e, err := dex.Resolve(kind, english)
+	if err == nil {
+		return e.Name
+	}
+	var unknown *dex.UnknownNameError
+	if errors.As(err, &unknown) && len(unknown.Suggestions) > 0 {
+		ps.warnings = append(ps.warnings, ps.errorAt(line, offset, CodeUnknownName, err))
+	}
+	return name
+}
+
+// canonicalNameLine replaces the species and the item of the receiver with their canonical names, see canonicalName.
+func (ps *parser) canonicalNameLine(p *Pokemon, line sourceLine) {
+	if !ps.opts.Canonicalize {
+		return
+	}
+	t := tokenizeNameLine(line.text)
+	p.Name = ps.canonicalName(line, t.species.start, dex.KindSpecies, p.Name)
+	p.Item = ps.canonicalName(line, t.item.start, dex.KindItem, p.Item)
+}
@@ -0,0 +1,114 @@
+package koffing
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/txfs19260817/koffing-go/dex"
+)
+
+const sloppySet = `smogon (weezing galar) @ lefties
+Ability: neutralizing gas
+EVs: 252 HP / 4 Def / 252 SpD
+bold Nature
+- will o wisp
+- Strange Steam
+- Pain Splt
+- hidden power fire`
+
+func ExampleParseOptions_canonicalize() {
+	p := &Pokemon{}
+	warnings, _ := p.FromShowdownWithOptions(sloppySet, ParseOptions{Canonicalize: true})
+	fmt.Println(p.Name, "@", p.Item, "/", p.Ability, "/", p.Nature, "/", p.Moves)
+	for _, w := range warnings {
+		fmt.Println(w)
+	}
+	// Output:
+	// Weezing-Galar @ Leftovers / Neutralizing Gas / Bold / [Will-O-Wisp Strange Steam Pain Splt Hidden Power [Fire]]
+	// team 0, pokemon 0, line 7, column 3: unknown move: Pain Splt (did you mean Pain Split?)
+}
+
+// TestParseOptions_canonicalize_strict checks that valid names which look like misspellings of other names of the dex,
+// e.g. "Tail Whip" of "Tailwind", do not fail strict parsing.
+func TestParseOptions_canonicalize_strict(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		set  string
+		want Pokemon
+	}{
+		{set: "Starly @ Oran Berry\nAbility: Keen Eye\n- Tail Whip\n- Quick Attack", want: Pokemon{Name: "Starly", Item: "Oran Berry", Moves: []string{"Tail Whip", "Quick Attack"}}},
+		{set: "Snorlax @ Leppa Berry\nAbility: Thick Fat\n- Rock Blast\n- Ice Ball", want: Pokemon{Name: "Snorlax", Item: "Leppa Berry", Moves: []string{"Rock Blast", "Ice Ball"}}},
+	}
+	for _, tt := range tests {
+		p := &Pokemon{}
+		_, err := p.FromShowdownWithOptions(tt.set, ParseOptions{Strict: true, Canonicalize: true})
+		assert.NoError(t, err, tt.set)
+		assert.Equal(t, tt.want.Name, p.Name)
+		assert.Equal(t, tt.want.Item, p.Item)
+		assert.Equal(t, tt.want.Moves, p.Moves)
+	}
+}
+
+func TestToID(t *testing.T) {
+	t.Parallel()
+	for _, name := range []string{"venusaur-gmax", "Venusaur-Gmax", "Venusaur Gmax"} {
+		assert.Equal(t, "venusaurgmax", ToID(name))
+	}
+}
+
+func TestParseOptions_canonicalize(t *testing.T) {
+	t.Parallel()
+	// the names are kept without the option
+	p := &Pokemon{}
+	warnings, err := p.FromShowdownWithOptions(sloppySet, ParseOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "weezing galar", p.Name)
+	assert.Equal(t, "lefties", p.Item)
+	assert.Equal(t, []string{"will o wisp", "Strange Steam", "Pain Splt", "hidden power fire"}, p.Moves)
+
+	warnings, err = p.FromShowdownWithOptions(sloppySet, ParseOptions{Canonicalize: true})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodeUnknownName, warnings[0].Code)
+	assert.Equal(t, 7, warnings[0].Line)
+	var unknown *dex.UnknownNameError
+	assert.True(t, errors.As(warnings[0].Err, &unknown))
+	assert.Equal(t, "Pain Split", unknown.Suggestions[0].Name)
+	assert.Equal(t, "smogon", p.Nickname)
+
+	// unknown names are warnings in strict mode too, since the dex may miss real names
+	warnings, err = p.FromShowdownWithOptions(sloppySet, ParseOptions{Canonicalize: true, Strict: true})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, CodeUnknownName, warnings[0].Code)
+
+	// the columns of misspelled names on the name line
+	warnings, err = p.FromShowdownWithOptions("Smogon (Wezing) @ Lefovers\nAbility: Levitate\n- Haze", ParseOptions{Canonicalize: true})
+	assert.NoError(t, err)
+	assert.Len(t, warnings, 2)
+	assert.Equal(t, 9, warnings[0].Column)
+	assert.Equal(t, 19, warnings[1].Column)
+	assert.Equal(t, "Wezing", p.Name)
+
+	// names in other languages are translated first
+	warnings, err = p.FromShowdownWithOptions("瓦斯弹 @ Überreste\nAbility: 飘浮\n- 守住", ParseOptions{Canonicalize: true})
+	assert.NoError(t, err)
+	assert.Empty(t, warnings)
+	assert.Equal(t, "Koffing", p.Name)
+	assert.Equal(t, "Leftovers", p.Item)
+	assert.Equal(t, "Levitate", p.Ability)
+	assert.Equal(t, []string{"Protect"}, p.Moves)
+
+	// canonical sets are unchanged
+	for _, team := range exportTeams(t) {
+		s, err := team.ToShowdown()
+		assert.NoError(t, err)
+		var got Team
+		_, err = got.FromShowdownWithOptions(s, ParseOptions{Canonicalize: true})
+		assert.NoError(t, err)
+		assert.Equal(t, team, got)
+	}
+}
@@ -0,0 +1,148 @@
+package koffing
+
+import (
+	"fmt"
+	"math"
+)
+
+// DVs are the determinant values of gens 1 and 2, in range [0, 15], which Showdown keeps as IVs of twice the value.
+// Spc is the DV of the Special stat, which is shared by Special Attack and Special Defense.
+type DVs struct {
+	Hp, Atk, Def, Spe, Spc int
+}
+
+// DVs returns the DVs represented by the IVs of the receiver, i.e. half of each IV.
+// Like in the games, the HP DV is derived from the others, thus Ivs.Hp is ignored.
+func (p Pokemon) DVs() DVs {
+	d := DVs{Atk: p.Ivs.Atk / 2, Def: p.Ivs.Def / 2, Spe: p.Ivs.Spe / 2, Spc: p.Ivs.Spa / 2}
+	d.Hp = d.Atk%2*8 + d.Def%2*4 + d.Spe%2*2 + d.Spc%2
+	return d
+}
+
+// SetDVs sets the IVs of the receiver to represent d, writing twice each DV, or 31 for a DV of 15 so that it is omitted in Showdown text.
+// The HP IV is derived from the other DVs, and the Special DV sets both the SpA and SpD IVs.
+func (p *Pokemon) SetDVs(d DVs) {
+	iv := func(dv int) int {
+		if dv == 15 {
+			return 31
+		}
+		return dv * 2
+	}
+	d.Hp = d.Atk%2*8 + d.Def%2*4 + d.Spe%2*2 + d.Spc%2
+	p.Ivs = Stats{Hp: iv(d.Hp), Atk: iv(d.Atk), Def: iv(d.Def), Spa: iv(d.Spc), Spd: iv(d.Spc), Spe: iv(d.Spe)}
+}
+
+// Gen2Shiny reports whether a Pokémon with the DVs is shiny in gen 2,
+// i.e. its Defense, Speed and Special DVs are 10, and its Attack DV is 2, 3, 6, 7, 10, 11, 14 or 15.
+func (d DVs) Gen2Shiny() bool {
+	return d.Def == 10 && d.Spe == 10 && d.Spc == 10 && d.Atk&2 == 2
+}
+
+// Gen2Gender returns the gender of a Pokémon with the DVs in gen 2, given the female ratio of its species, e.g. 0.125 for starters.
+// It is "F" if the Attack DV is below 16 times the ratio, and "M" otherwise. The caller handles genderless species.
+func (d DVs) Gen2Gender(femaleRatio float64) string {
+	if float64(d.Atk) < femaleRatio*16 {
+		return "F"
+	}
+	return "M"
+}
+
+// StatExp returns the stat experience of gens 1 and 2 in range [0, 65535] represented by the EVs of the receiver,
+// which Showdown keeps as EVs whose square is the stat experience.
+func (p Pokemon) StatExp() Stats {
+	exp := func(ev int) int {
+		if ev*ev > 65535 {
+			return 65535
+		}
+		return ev * ev
+	}
+	return Stats{Hp: exp(p.Evs.Hp), Atk: exp(p.Evs.Atk), Def: exp(p.Evs.Def), Spa: exp(p.Evs.Spa), Spd: exp(p.Evs.Spd), Spe: exp(p.Evs.Spe)}
+}
+
+// SetStatExp sets the EVs of the receiver to represent the stat experience s, i.e. the rounded up square root of each value.
+func (p *Pokemon) SetStatExp(s Stats) {
+	ev := func(exp int) int {
+		if exp <= 0 {
+			return 0
+		}
+		if exp >= 65025 {
+			return 255
+		}
+		return int(math.Ceil(math.Sqrt(float64(exp))))
+	}
+	p.Evs = Stats{Hp: ev(s.Hp), Atk: ev(s.Atk), Def: ev(s.Def), Spa: ev(s.Spa), Spd: ev(s.Spd), Spe: ev(s.Spe)}
+}
+
+// ValidateGen is like Validate, but applies the rules of the given generation, e.g. from the format of a Team.
+// Pokémon have neither abilities nor natures in gens 1 and 2, where EVs go up to 255 and the Special stat is shared.
+// A gen of 0 means unknown, for which ValidateGen is Validate.
+func (p Pokemon) ValidateGen(gen int) error {
+	var err error
+	if gen == 1 || gen == 2 {
+		err = p.validateRetro(gen)
+	} else {
+		err = p.Validate()
+	}
+	if err != nil {
+		return err
+	}
+	return p.validateHiddenPower(gen)
+}
+
+// validateRetro checks a Pokémon of gen 1 or 2.
+func (p Pokemon) validateRetro(gen int) error {
+	if len(p.Name) == 0 {
+		return fmt.Errorf("name is required")
+	}
+	if len(p.Ability) > 0 && p.Ability != "No Ability" {
+		return fmt.Errorf("abilities do not exist in gen %d, yours: %s", gen, p.Ability)
+	}
+	if len(p.Nature) > 0 {
+		return fmt.Errorf("natures do not exist in gen %d, yours: %s", gen, p.Nature)
+	}
+	if err := p.validateValues(255); err != nil {
+		return err
+	}
+	if p.Evs.Spa != p.Evs.Spd {
+		return fmt.Errorf("the Special stat is shared in gen %d, but the Spa ev %d differs from the Spd ev %d", gen, p.Evs.Spa, p.Evs.Spd)
+	}
+	if p.Ivs.Spa/2 != p.Ivs.Spd/2 {
+		return fmt.Errorf("the Special stat is shared in gen %d, but the Spa iv %d differs from the Spd iv %d", gen, p.Ivs.Spa, p.Ivs.Spd)
+	}
+	if len(p.TeraType) > 0 || p.Gigantamax || p.DynamaxLevel != 0 && p.DynamaxLevel != 10 {
+		return fmt.Errorf("tera types and dynamax do not exist in gen %d", gen)
+	}
+	if gen == 1 {
+		if len(p.Item) > 0 {
+			return fmt.Errorf("items do not exist in gen 1, yours: %s", p.Item)
+		}
+		if p.Shiny || len(p.Gender) > 0 {
+			return fmt.Errorf("shininess and genders do not exist in gen 1")
+		}
+		return nil
+	}
+	if p.Shiny && !p.DVs().Gen2Shiny() {
+		return fmt.Errorf("shiny Pokémon need DVs of 10 in Def, Spe and Spc in gen 2, yours: %+v", p.DVs())
+	}
+	return p.validateGen2Gender()
+}
+
+// validateGen2Gender checks the gender against the one the species and the Attack DV give in gen 2.
+// Unknown species are not checked.
+func (p Pokemon) validateGen2Gender() error {
+	species, ok := p.SpeciesGen(2)
+	if !ok || len(p.Gender) == 0 {
+		return nil
+	}
+	want := species.Gender
+	switch want {
+	case "N":
+		return fmt.Errorf("%s is genderless, yours: %s", species.Name, p.Gender)
+	case "":
+		want = p.DVs().Gen2Gender(species.GenderRatio.F)
+	}
+	if p.Gender != want {
+		return fmt.Errorf("the Attack DV %d makes %s %s in gen 2, yours: %s", p.DVs().Atk, species.Name, want, p.Gender)
+	}
+	return nil
+}
@@ -0,0 +1,234 @@
+package koffing
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const gscTeam = `=== [gen2ou] GSC ===
+
+Snorlax @ Leftovers
+- Body Slam
+- Curse
+- Rest
+- Sleep Talk
+
+Zapdos @ Leftovers
+IVs: 26 Def
+- Thunder
+- Hidden Power [Ice]
+- Rest
+- Sleep Talk
+`
+
+func ExamplePokemon_DVs() {
+	p := Pokemon{Ivs: Stats{Hp: 31, Atk: 31, Def: 26, Spa: 31, Spd: 31, Spe: 31}}
+	fmt.Printf("%+v\n", p.DVs())
+	hp, _ := p.HiddenPower(2)
+	fmt.Println(hp.Type)
+	// Output: {Hp:15 Atk:15 Def:13 Spe:15 Spc:15}
+	// Ice
+}
+
+func TestPokemon_DVs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ivs  Stats
+		want DVs
+	}{
+		{ivs: Stats{31, 31, 31, 31, 31, 31}, want: DVs{Hp: 15, Atk: 15, Def: 15, Spe: 15, Spc: 15}},
+		{ivs: Stats{}, want: DVs{}},
+		// the HP DV comes from the lowest bits of the others, whatever the HP IV is
+		{ivs: Stats{0, 28, 26, 20, 20, 31}, want: DVs{Hp: 6, Atk: 14, Def: 13, Spe: 15, Spc: 10}},
+	}
+	for _, tt := range tests {
+		p := Pokemon{Ivs: tt.ivs}
+		assert.Equal(t, tt.want, p.DVs())
+		p.SetDVs(tt.want)
+		assert.Equal(t, tt.want, p.DVs())
+	}
+	p := Pokemon{}
+	p.SetDVs(DVs{Hp: 0, Atk: 15, Def: 10, Spe: 10, Spc: 10})
+	assert.Equal(t, Stats{Hp: 16, Atk: 31, Def: 20, Spa: 20, Spd: 20, Spe: 20}, p.Ivs)
+}
+
+func TestDVs_Gen2Shiny(t *testing.T) {
+	t.Parallel()
+	for atk := 0; atk < 16; atk++ {
+		want := atk == 2 || atk == 3 || atk == 6 || atk == 7 || atk == 10 || atk == 11 || atk == 14 || atk == 15
+		assert.Equal(t, want, DVs{Atk: atk, Def: 10, Spe: 10, Spc: 10}.Gen2Shiny(), "atk %d", atk)
+	}
+	assert.False(t, DVs{Atk: 15, Def: 15, Spe: 10, Spc: 10}.Gen2Shiny())
+}
+
+func TestDVs_Gen2Gender(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		ratio float64
+		atk   int
+		want  string
+	}{
+		{ratio: 0.125, atk: 1, want: "F"},
+		{ratio: 0.125, atk: 2, want: "M"},
+		{ratio: 0.5, atk: 7, want: "F"},
+		{ratio: 0.5, atk: 8, want: "M"},
+		{ratio: 0, atk: 0, want: "M"},
+		{ratio: 1, atk: 15, want: "F"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, DVs{Atk: tt.atk}.Gen2Gender(tt.ratio), "%v %d", tt.ratio, tt.atk)
+	}
+}
+
+func TestPokemon_StatExp(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{Evs: Stats{Hp: 252, Atk: 255, Def: 0, Spa: 100, Spd: 100, Spe: 1}}
+	assert.Equal(t, Stats{Hp: 63504, Atk: 65025, Def: 0, Spa: 10000, Spd: 10000, Spe: 1}, p.StatExp())
+	p.SetStatExp(Stats{Hp: 65535, Atk: 65025, Def: 0, Spa: 10001, Spd: 10000, Spe: -1})
+	assert.Equal(t, Stats{Hp: 255, Atk: 255, Def: 0, Spa: 101, Spd: 100, Spe: 0}, p.Evs)
+}
+
+func TestPokemon_ValidateGen(t *testing.T) {
+	t.Parallel()
+	retro := Pokemon{Name: "Snorlax", Happiness: 255, Evs: Stats{255, 255, 255, 255, 255, 255}, Ivs: Stats{31, 31, 31, 31, 31, 31}, Moves: []string{"Body Slam"}}
+	modern := Pokemon{Name: "Snorlax", Ability: "Thick Fat", Nature: "Adamant", Happiness: 255, Ivs: Stats{31, 31, 31, 31, 31, 31}, Moves: []string{"Body Slam"}}
+	tests := []struct {
+		name    string
+		p       func(p Pokemon) Pokemon
+		base    Pokemon
+		gen     int
+		wantErr bool
+	}{
+		{name: "gen 1", base: retro, gen: 1},
+		{name: "gen 2", base: retro, gen: 2},
+		{name: "gen 1 rejected by modern rules", base: retro, gen: 3, wantErr: true},
+		{name: "unknown gen", base: retro, gen: 0, wantErr: true},
+		{name: "modern", base: modern, gen: 8},
+		{name: "nature in gen 2", base: modern, p: func(p Pokemon) Pokemon { p.Ability = ""; return p }, gen: 2, wantErr: true},
+		{name: "ability in gen 2", base: modern, p: func(p Pokemon) Pokemon { p.Nature = ""; return p }, gen: 2, wantErr: true},
+		{name: "no ability", base: retro, p: func(p Pokemon) Pokemon { p.Ability = "No Ability"; return p }, gen: 2},
+		{name: "EV above 255", base: retro, p: func(p Pokemon) Pokemon { p.Evs.Hp = 256; return p }, gen: 1, wantErr: true},
+		{name: "split Special EVs", base: retro, p: func(p Pokemon) Pokemon { p.Evs.Spa = 0; return p }, gen: 2, wantErr: true},
+		{name: "split Special DVs", base: retro, p: func(p Pokemon) Pokemon { p.Ivs.Spd = 20; return p }, gen: 1, wantErr: true},
+		{name: "same Special DV", base: retro, p: func(p Pokemon) Pokemon { p.Ivs.Spd = 30; return p }, gen: 1},
+		{name: "item in gen 1", base: retro, p: func(p Pokemon) Pokemon { p.Item = "Leftovers"; return p }, gen: 1, wantErr: true},
+		{name: "item in gen 2", base: retro, p: func(p Pokemon) Pokemon { p.Item = "Leftovers"; return p }, gen: 2},
+		{name: "gender in gen 1", base: retro, p: func(p Pokemon) Pokemon { p.Gender = "M"; return p }, gen: 1, wantErr: true},
+		{name: "tera type in gen 2", base: retro, p: func(p Pokemon) Pokemon { p.TeraType = "Ghost"; return p }, gen: 2, wantErr: true},
+		{name: "shiny without shiny DVs", base: retro, p: func(p Pokemon) Pokemon { p.Shiny = true; return p }, gen: 2, wantErr: true},
+		{name: "shiny DVs", base: retro, p: func(p Pokemon) Pokemon { p.Shiny = true; p.SetDVs(DVs{Atk: 15, Def: 10, Spe: 10, Spc: 10}); return p }, gen: 2},
+		{name: "gen 2 gender", base: retro, p: func(p Pokemon) Pokemon { p.Gender = "M"; return p }, gen: 2},
+		{name: "gen 2 gender conflicting with the Attack DV", base: retro, p: func(p Pokemon) Pokemon { p.Gender = "F"; return p }, gen: 2, wantErr: true},
+		{name: "gen 2 female", base: retro, p: func(p Pokemon) Pokemon { p.Gender = "F"; p.SetDVs(DVs{Atk: 1, Def: 15, Spe: 15, Spc: 15}); return p }, gen: 2},
+		{name: "gen 2 male with a low Attack DV", base: retro, p: func(p Pokemon) Pokemon { p.Gender = "M"; p.SetDVs(DVs{Atk: 1, Def: 15, Spe: 15, Spc: 15}); return p }, gen: 2, wantErr: true},
+		{name: "gen 2 genderless", base: retro, p: func(p Pokemon) Pokemon { p.Name = "Zapdos"; p.Gender = "M"; return p }, gen: 2, wantErr: true},
+		{name: "gen 2 gender of an unknown species", base: retro, p: func(p Pokemon) Pokemon { p.Name = "Fakemon"; p.Gender = "F"; return p }, gen: 2},
+		{name: "hidden power", base: retro, p: func(p Pokemon) Pokemon { p.Moves = []string{"Hidden Power [Ice]"}; return p }, gen: 2, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := tt.base
+			if tt.p != nil {
+				p = tt.p(p)
+			}
+			err := p.ValidateGen(tt.gen)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestTeam_gen2(t *testing.T) {
+	t.Parallel()
+	team := Team{}
+	assert.NoError(t, team.FromShowdown(gscTeam))
+	assert.Equal(t, "gen2ou", team.Format)
+	// sets without an EVs line have EVs of 252 in gens 1 and 2, which are not written into the raw values
+	assert.Equal(t, Stats{}, team.Pokemon[0].Evs)
+	assert.Equal(t, Stats{252, 252, 252, 252, 252, 252}, team.Effective().Pokemon[0].Evs)
+	assert.NoError(t, team.Validate())
+	assert.NoError(t, team.Effective().Validate())
+
+	s, err := team.ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "Zapdos @ Leftovers  \nIVs: 26 Def  \n")
+	roundTrip := Team{}
+	assert.NoError(t, roundTrip.FromShowdown(s))
+	assert.Equal(t, team, roundTrip)
+
+	// the same team is invalid in a later generation, where abilities and natures are required
+	team.Format = "gen3ou"
+	assert.Error(t, team.Validate())
+
+	// an EVs line sets the unlisted EVs to 0 like Showdown does
+	p := Pokemon{}
+	_, err = p.FromShowdownWithOptions("Tauros\nEVs: 252 Atk\n- Body Slam\n- Blizzard", ParseOptions{Gen: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{Atk: 252}, p.Evs)
+	assert.Equal(t, Stats{Atk: 252}, p.Effective(DefaultsFor("gen1ou")).Evs)
+	assert.NoError(t, p.ValidateGen(1))
+	_, err = p.FromShowdownWithOptions("Tauros\n- Body Slam\n- Blizzard", ParseOptions{Gen: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, Stats{}, p.Evs)
+	assert.Equal(t, Stats{252, 252, 252, 252, 252, 252}, p.Effective(DefaultsFor("gen1ou")).Evs)
+	assert.Equal(t, Stats{}, p.Effective(DefaultsFor("gen3ou")).Evs)
+
+	// the defaults of each team of a collection come from its format
+	d := NewDecoder(strings.NewReader("=== [gen8] A ===\n\nTauros\nAbility: Intimidate\n- Body Slam\n\n" + gscTeam))
+	var evs []Stats
+	for d.Next() {
+		evs = append(evs, d.Pokemon().Effective(d.Header().Defaults()).Evs)
+	}
+	assert.NoError(t, d.Err())
+	assert.Equal(t, []Stats{{}, {252, 252, 252, 252, 252, 252}, {252, 252, 252, 252, 252, 252}}, evs)
+}
+
+// TestTeamCollection_retro_golden checks the teams of gens 1 and 2 in testdata/retro, whose Special stat is written as Spc,
+// which Teams.export writes as SpA and SpD.
+func TestTeamCollection_retro_golden(t *testing.T) {
+	t.Parallel()
+	b, err := os.ReadFile("testdata/retro/teams.txt")
+	assert.NoError(t, err)
+	c := TeamCollection{}
+	assert.NoError(t, c.FromShowdown(string(b)))
+	assert.NoError(t, c.Validate())
+	rby, gsc := c.Teams[0], c.Teams[1]
+	assert.Equal(t, Stats{Hp: 252, Def: 252, Spa: 252, Spd: 252, Spe: 252}, rby.Pokemon[1].Evs)
+	assert.Equal(t, Stats{252, 252, 252, 252, 252, 252}, rby.Effective().Pokemon[0].Evs)
+	assert.Equal(t, Stats{Hp: 31, Atk: 31, Def: 20, Spa: 20, Spd: 20, Spe: 20}, gsc.Pokemon[0].Ivs)
+	assert.True(t, gsc.Pokemon[0].DVs().Gen2Shiny())
+
+	s, err := c.ToShowdownWithOptions(ShowdownOptions{Spc: true})
+	assert.NoError(t, err)
+	assert.Equal(t, string(b), s)
+	s, err = c.ToShowdown()
+	assert.NoError(t, err)
+	assert.Equal(t, regexp.MustCompile(`(\d+) Spc`).ReplaceAllString(string(b), "$1 SpA / $1 SpD"), s)
+
+	// without a generation, the Special stat is SpA
+	p := Pokemon{}
+	assert.NoError(t, p.FromShowdown("Chansey\nEVs: 252 Spc\n- Seismic Toss"))
+	assert.Equal(t, Stats{Spa: 252}, p.Evs)
+	s, err = p.ToShowdownWithOptions(ShowdownOptions{Gen: 1, Spc: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Chansey  \nEVs: 252 SpA  \n- Seismic Toss  \n", s)
+	p.Evs.Spd = 252
+	s, err = p.ToShowdownWithOptions(ShowdownOptions{Gen: 1, Spc: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Chansey  \nEVs: 252 Spc  \n- Seismic Toss  \n", s)
+	s, err = p.ToShowdownWithOptions(ShowdownOptions{Gen: 1})
+	assert.NoError(t, err)
+	assert.Equal(t, "Chansey  \nEVs: 252 SpA / 252 SpD  \n- Seismic Toss  \n", s)
+	// Spc is only written in gens 1 and 2
+	s, err = p.ToShowdownWithOptions(ShowdownOptions{Gen: 3, Spc: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "Chansey  \nEVs: 252 SpA / 252 SpD  \n- Seismic Toss  \n", s)
+}
@@ -0,0 +1,362 @@
+package koffing
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// The scanner reads Showdown text in a single pass without regexes. It accepts exactly what the regexes
+// the parser used to be written with accept, which are kept in the tests as the reference, e.g. `^Ability:\s?(.*)$`.
+
+// sourceLine is a non-blank line of the input with its position.
+type sourceLine struct {
+	text string // the line without surrounding spaces
+	raw  string // the line without the line break
+	num  int    // 1-based line number
+	col  int    // 1-based column where text starts
+}
+
+// newSourceLine returns the line number num without its line break.
+func newSourceLine(raw string, num int) sourceLine {
+	text := strings.TrimLeftFunc(raw, unicode.IsSpace)
+	line := sourceLine{raw: raw, num: num, col: len(raw) - len(text) + 1}
+	line.text = strings.TrimRightFunc(text, unicode.IsSpace)
+	return line
+}
+
+// splitBlocks splits a multi-line string into blocks of non-blank lines.
+// Blocks are separated by blank lines, and a team header line always forms a block by itself.
+func splitBlocks(s string) [][]sourceLine {
+	_, blocks := scanBlocks(s)
+	return blocks
+}
+
+// splitLines returns all non-blank lines of a multi-line string.
+func splitLines(s string) []sourceLine {
+	lines, _ := scanBlocks(s)
+	return lines
+}
+
+// scanBlocks returns the non-blank lines of a multi-line string, and the blocks they form as slices of the lines.
+// The lines refer to s without copying it.
+func scanBlocks(s string) (lines []sourceLine, blocks [][]sourceLine) {
+	lines = make([]sourceLine, 0, strings.Count(s, "\n")+1)
+	blocks = make([][]sourceLine, 0, 7)
+	start := 0 // the first line of the current block
+	flush := func() {
+		if len(lines) > start {
+			blocks = append(blocks, lines[start:len(lines):len(lines)])
+			start = len(lines)
+		}
+	}
+	for num := 1; ; num++ {
+		raw, rest, found := s, "", false
+		if i := strings.IndexByte(s, '\n'); i >= 0 {
+			raw, rest, found = s[:i], s[i+1:], true
+		}
+		// "\r\n" is a line break, and a "\r" at the end of the input is dropped too
+		raw = strings.TrimSuffix(raw, "\r")
+		line := newSourceLine(raw, num)
+		switch {
+		case len(line.text) == 0:
+			flush()
+		case isHeaderLine(line.text):
+			flush()
+			lines = append(lines, line)
+			flush()
+		default:
+			lines = append(lines, line)
+		}
+		if !found {
+			break
+		}
+		s = rest
+	}
+	flush()
+	return lines, blocks
+}
+
+// trimLines trims space for each element in input string slice,
+// and only keep non-empty strings.
+func trimLines(lines []string) []string {
+	res := make([]string, 0, len(lines))
+	for _, line := range lines {
+		if p := strings.TrimSpace(line); len(p) > 0 {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// isSpace reports whether c is a space as matched by \s, i.e. an ASCII space, tab, line feed, form feed or carriage return.
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\f' || c == '\r'
+}
+
+// prefixFold returns the length of the prefix of s which equals prefix under Unicode case folding, like (?i) does, or -1.
+func prefixFold(s, prefix string) int {
+	n := 0
+	for _, want := range prefix {
+		if n >= len(s) {
+			return -1
+		}
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if r != want && !equalFoldRune(r, want) {
+			return -1
+		}
+		n += size
+	}
+	return n
+}
+
+// equalFoldRune reports whether a and b are equal under simple Unicode case folding.
+func equalFoldRune(a, b rune) bool {
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+	return false
+}
+
+// skipSpace returns the position after an optional space at i, like \s? does.
+func skipSpace(s string, i int) int {
+	if i < len(s) && isSpace(s[i]) {
+		return i + 1
+	}
+	return i
+}
+
+// anyValue returns the span of the rest of s from i, or false if it holds a line feed, like (.*)$ does.
+func anyValue(s string, i int) (span, bool) {
+	if strings.IndexByte(s[i:], '\n') >= 0 {
+		return span{}, false
+	}
+	return span{i, len(s)}, true
+}
+
+// digitsValue returns the span of the rest of s from i if it is 1 to max ASCII digits, like ([0-9]{1,max})$ does.
+func digitsValue(s string, i, max int) (span, bool) {
+	n := len(s) - i
+	if n < 1 || n > max {
+		return span{}, false
+	}
+	for j := i; j < len(s); j++ {
+		if s[j] < '0' || s[j] > '9' {
+			return span{}, false
+		}
+	}
+	return span{i, len(s)}, true
+}
+
+// yesNoValue returns the span of the rest of s from i if it is "Yes" or "No" in any case, like (?i)(Yes|No)$ does.
+func yesNoValue(s string, i int) (span, bool) {
+	rest := s[i:]
+	if n := prefixFold(rest, "yes"); n == len(rest) {
+		return span{i, len(s)}, true
+	}
+	if n := prefixFold(rest, "no"); n == len(rest) {
+		return span{i, len(s)}, true
+	}
+	return span{}, false
+}
+
+// scanLine returns the kind of a trimmed line of a set other than the name line, and the span of its value:
+// the value of a field like "Levitate" of "Ability: Levitate", the stats of EVs/IVs lines, or the move of a move line.
+// When several kinds match, the first one in the order of LineKind wins, save for natures which come before EVs, IVs and moves.
+func scanLine(text string) (LineKind, span) {
+	var (
+		v  span
+		ok bool
+	)
+	switch {
+	case strings.HasPrefix(text, "Ability:"):
+		if v, ok = anyValue(text, skipSpace(text, len("Ability:"))); ok {
+			return LineAbility, v
+		}
+	case strings.HasPrefix(text, "Level:"):
+		if v, ok = digitsValue(text, skipSpace(text, len("Level:")), 3); ok {
+			return LineLevel, v
+		}
+	case strings.HasPrefix(text, "Happiness:"):
+		if v, ok = digitsValue(text, skipSpace(text, len("Happiness:")), 3); ok {
+			return LineHappiness, v
+		}
+	case strings.HasPrefix(text, "Pokeball:"):
+		if v, ok = anyValue(text, skipSpace(text, len("Pokeball:"))); ok {
+			return LinePokeball, v
+		}
+	case strings.HasPrefix(text, "Hidden Power:"):
+		if v, ok = anyValue(text, skipSpace(text, len("Hidden Power:"))); ok {
+			return LineHiddenPower, v
+		}
+	case strings.HasPrefix(text, "Dynamax Level:"):
+		if v, ok = digitsValue(text, skipSpace(text, len("Dynamax Level:")), 2); ok {
+			return LineDynamaxLevel, v
+		}
+	case strings.HasPrefix(text, "Tera Type:"):
+		if v, ok = anyValue(text, skipSpace(text, len("Tera Type:"))); ok {
+			return LineTeraType, v
+		}
+	}
+	// case-insensitive labels
+	if n := prefixFold(text, "shiny:"); n > 0 {
+		if v, ok = yesNoValue(text, skipSpace(text, n)); ok {
+			return LineShiny, v
+		}
+	} else if n := prefixFold(text, "gigantamax:"); n > 0 {
+		if v, ok = yesNoValue(text, skipSpace(text, n)); ok {
+			return LineGigantamax, v
+		}
+	}
+	if v, ok = natureValue(text); ok {
+		return LineNature, v
+	}
+	if n := prefixFold(text, "evs:"); n > 0 {
+		if v, ok = anyValue(text, skipSpace(text, n)); ok {
+			return LineEVs, v
+		}
+	} else if n := prefixFold(text, "ivs:"); n > 0 {
+		if v, ok = anyValue(text, skipSpace(text, n)); ok {
+			return LineIVs, v
+		}
+	}
+	if len(text) > 0 && (text[0] == '-' || text[0] == '~') {
+		if v, ok = anyValue(text, skipSpace(text, 1)); ok {
+			return LineMove, v
+		}
+	}
+	return LineUnknown, span{}
+}
+
+// natureValue returns the span of the nature of a line like "Bold Nature", like ^(.*)\s+Nature$ does.
+func natureValue(text string) (span, bool) {
+	n := len(text) - len("Nature")
+	if n < 1 || text[n:] != "Nature" || !isSpace(text[n-1]) {
+		return span{}, false
+	}
+	// the value may end with spaces but not hold a line feed, and is followed by at least one space
+	end := n - 1
+	if nl := strings.IndexByte(text[:end], '\n'); nl >= 0 {
+		for i := nl; i < end; i++ {
+			if !isSpace(text[i]) {
+				return span{}, false
+			}
+		}
+		end = nl
+	}
+	return span{0, end}, true
+}
+
+// isHeaderLine reports whether a trimmed line is a team header line like "=== [gen9] Folder/Name ===".
+func isHeaderLine(text string) bool {
+	_, _, ok := scanHeader(text)
+	return ok
+}
+
+// scanHeader returns the spans of the format and the name of a trimmed team header line, like ^===\s+(?:\[(.*)\]\s+)?(.*)\s+===$ does.
+// The format is empty if the line has no format tag, in which case a bracketed name is the name.
+func scanHeader(text string) (format, name span, ok bool) {
+	if len(text) < len("=== ===")+1 || !strings.HasPrefix(text, "===") || !strings.HasSuffix(text, "===") ||
+		strings.IndexByte(text, '\n') >= 0 {
+		return span{}, span{}, false
+	}
+	// text[3:end+1] is the middle, which starts and ends with a space
+	end := len(text) - len("===") - 1
+	if end <= 3 || !isSpace(text[3]) || !isSpace(text[end]) {
+		return span{}, span{}, false
+	}
+	i := 3
+	for i < end && isSpace(text[i]) {
+		i++
+	}
+	if i < end && text[i] == '[' {
+		// the last "]" followed by a space which is not the one before "==="
+		for k := end - 2; k > i; k-- {
+			if text[k] == ']' && isSpace(text[k+1]) {
+				j := k + 1
+				for j < end && isSpace(text[j]) {
+					j++
+				}
+				return span{i + 1, k}, span{j, end}, true
+			}
+		}
+	}
+	return span{}, span{i, end}, true
+}
+
+// parseStatsLine parses the value of an EVs/IVs line, e.g. "252 HP / 4 Def", whose unlisted stats are defaultValue.
+// In gens 1 and 2, the shared Special stat, e.g. "252 Spc", sets both SpA and SpD; in other generations it is SpA.
+// On failure, offset is the position of the offending stat in value.
+func parseStatsLine(value string, defaultValue, gen int) (stats Stats, offset int, err error) {
+	stats = Stats{Hp: defaultValue, Atk: defaultValue, Def: defaultValue, Spa: defaultValue, Spd: defaultValue, Spe: defaultValue}
+	for {
+		part := value[offset:]
+		slash := strings.IndexByte(part, '/')
+		if slash >= 0 {
+			part = part[:slash]
+		}
+		start := offset + len(part) - len(strings.TrimLeft(part, " \t"))
+		if num, name := cutField(strings.TrimSpace(part)); len(num) > 0 {
+			if len(name) == 0 {
+				return Stats{}, start, fmt.Errorf("missing stat name: %s", num)
+			}
+			n, err := strconv.Atoi(num)
+			if err != nil {
+				return Stats{}, start, err
+			}
+			label, ok := statLabel(name)
+			if !ok {
+				return Stats{}, start, fmt.Errorf("unknown stat: %s", name)
+			}
+			stats.set(label, n)
+			if (gen == 1 || gen == 2) && isSpecial(name) {
+				stats.Spd = n
+			}
+		}
+		if slash < 0 {
+			return stats, 0, nil
+		}
+		offset += slash + 1
+	}
+}
+
+// cutField splits a trimmed string at its first space into a field and the rest, whose inner spaces are collapsed like strings.Fields does.
+func cutField(s string) (field, rest string) {
+	i := strings.IndexFunc(s, unicode.IsSpace)
+	if i < 0 {
+		return s, ""
+	}
+	field, rest = s[:i], strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+	if strings.IndexFunc(rest, unicode.IsSpace) >= 0 {
+		rest = strings.Join(strings.Fields(rest), " ")
+	}
+	return field, rest
+}
+
+// isSpecial reports whether a stat name is the Special stat of gens 1 and 2, e.g. "Spc".
+func isSpecial(name string) bool {
+	id := ToID(name)
+	return id == "spc" || id == "special"
+}
+
+// set sets the stat with the label used in EVs/IVs lines.
+func (s *Stats) set(label string, value int) {
+	switch label {
+	case "HP":
+		s.Hp = value
+	case "Atk":
+		s.Atk = value
+	case "Def":
+		s.Def = value
+	case "SpA":
+		s.Spa = value
+	case "SpD":
+		s.Spd = value
+	case "Spe":
+		s.Spe = value
+	}
+}
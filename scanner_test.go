@@ -0,0 +1,243 @@
+package koffing
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// The regexes the parser used to be written with, which are the reference of the scanner.
+var (
+	teamTagRegex      = regexp.MustCompile(`^===\s+(?:\[(.*)\]\s+)?(.*)\s+===$`)
+	abilityRegex      = regexp.MustCompile(`^Ability:\s?(.*)$`)
+	levelRegex        = regexp.MustCompile(`^Level:\s?([0-9]{1,3})$`)
+	shinyRegex        = regexp.MustCompile(`^(?i)Shiny:\s?(Yes|No)$`)
+	happinessRegex    = regexp.MustCompile(`^Happiness:\s?([0-9]{1,3})$`)
+	pokeballRegex     = regexp.MustCompile(`^Pokeball:\s?(.*)$`)
+	hiddenPowerRegex  = regexp.MustCompile(`^Hidden Power:\s?(.*)$`)
+	dynamaxLevelRegex = regexp.MustCompile(`^Dynamax Level:\s?([0-9]{1,2})$`)
+	gigantamaxRegex   = regexp.MustCompile(`^(?i)Gigantamax:\s?(Yes|No)$`)
+	teraTypeRegex     = regexp.MustCompile(`^Tera Type:\s?(.*)$`)
+	eivsRegex         = regexp.MustCompile(`(?i)^([EI]Vs):\s?(.*)$`)
+	natureRegex       = regexp.MustCompile(`^(.*)\s+Nature$`)
+	moveRegex         = regexp.MustCompile(`^[-~]\s?(.*)$`)
+)
+
+// referenceScanLine classifies a line with the reference regexes, in the order the parser used to try them.
+func referenceScanLine(text string) (LineKind, span) {
+	for _, r := range []struct {
+		kind LineKind
+		re   *regexp.Regexp
+	}{
+		{LineAbility, abilityRegex}, {LineLevel, levelRegex}, {LineShiny, shinyRegex}, {LineHappiness, happinessRegex},
+		{LinePokeball, pokeballRegex}, {LineHiddenPower, hiddenPowerRegex}, {LineDynamaxLevel, dynamaxLevelRegex},
+		{LineGigantamax, gigantamaxRegex}, {LineTeraType, teraTypeRegex}, {LineNature, natureRegex},
+		{LineEVs, eivsRegex}, {LineMove, moveRegex},
+	} {
+		if m := r.re.FindStringSubmatchIndex(text); m != nil {
+			kind, v := r.kind, span{m[2], m[3]}
+			if kind == LineEVs {
+				if strings.EqualFold(text[:1], "I") {
+					kind = LineIVs
+				}
+				v = span{m[4], m[5]}
+			}
+			return kind, v
+		}
+	}
+	return LineUnknown, span{}
+}
+
+// referenceScanHeader parses a team header line with the reference regex.
+func referenceScanHeader(text string) (format, name string, ok bool) {
+	m := teamTagRegex.FindStringSubmatch(text)
+	if m == nil {
+		return "", "", false
+	}
+	return strings.TrimSpace(m[1]), strings.TrimSpace(m[2]), true
+}
+
+// scanLineSeeds are lines on the edges of the grammar.
+var scanLineSeeds = []string{
+	"Ability: Levitate", "Ability:Levitate", "Ability:  Levitate", "Ability:", "Ability Levitate", "ability: Levitate", "Ability: Calm Nature",
+	"Level: 5", "Level:5", "Level: 100", "Level: 1000", "Level: ", "Level:  5", "Level: 5x", "Level: -1", "Level: 5 Nature",
+	"Shiny: Yes", "shiny: yes", "SHINY:NO", "Shiny: True", "ſhiny: yeſ", "Shiny: Yes ", "Shiny:  Yes",
+	"Happiness: 255", "Happiness: -1", "Happiness:0", "Happiness: 2555",
+	"Pokeball: Poke Ball", "Pokeball:", "Hidden Power: Fire", "- Hidden Power [Fire]", "Hidden Power:Fire",
+	"Dynamax Level: 5", "Dynamax Level: 100", "Dynamax Level:10", "Gigantamax: Yes", "gigantamax: no", "Gigantamax: Maybe",
+	"Tera Type: Fairy", "Tera Type:", "Tera type: Fairy",
+	"Bold Nature", "BoldNature", "Bold  Nature", "Bold\tNature", " Nature", "Nature", "x Nature", "- Bold Nature", "EVs: 4 HP Nature", "Bold Nature ",
+	"EVs: 36 HP / 236 Def / 236 SpD", "IVs: 31 HP / 30 Atk", "IVs31 HP / 30 Atk", "evs: 4 hp", "iVS:", "EVſ: 4 HP", "EV: 4 HP",
+	"- Protect", "-Protect", "~ Protect", "-  Protect", "-", "Protect", "—Protect",
+	"Ability: a\nb", "Bold\nNature", "Bold \n Nature", "a\nb Nature", "- a\nb", "Level:\n5", "", "\xff", "Level: ５",
+}
+
+func Test_scanLine(t *testing.T) {
+	t.Parallel()
+	for _, text := range scanLineSeeds {
+		wantKind, wantValue := referenceScanLine(text)
+		kind, value := scanLine(text)
+		assert.Equal(t, wantKind, kind, "%q", text)
+		if wantKind != LineUnknown {
+			assert.Equal(t, wantValue, value, "%q", text)
+		}
+	}
+	kind, value := scanLine("Ability: Levitate")
+	assert.Equal(t, LineAbility, kind)
+	assert.Equal(t, "Levitate", value.of("Ability: Levitate"))
+	kind, value = scanLine("Bold  Nature")
+	assert.Equal(t, LineNature, kind)
+	assert.Equal(t, "Bold ", value.of("Bold  Nature"))
+}
+
+// headerSeeds are team header lines on the edges of the grammar.
+var headerSeeds = []string{
+	"=== [gen7] Folder 1/Example Team ===", "======", "=== [gen8vgc2021] Untitled 10 ===", "=== Untitled 1 ===",
+	"=== ===", "===  ===", "===   ===", "=== [gen9] ===", "=== [gen9]  ===", "=== [gen9] x ===", "=== [a] [b] c ===",
+	"=== [a]b] c ===", "=== [a] b] c ===", "===\t[gen9]\tName\t===", "===[gen9] Name ===", "=== [gen9] Name===",
+	"=== [] Name ===", "=== [gen9 Name ===", "=== a ===b ===", "=== === ===", "==== x ====", "=== x", "x ===",
+}
+
+func Test_scanHeader(t *testing.T) {
+	t.Parallel()
+	for _, text := range headerSeeds {
+		wantFormat, wantName, wantOk := referenceScanHeader(text)
+		format, name, ok := scanHeader(text)
+		assert.Equal(t, wantOk, ok, "%q", text)
+		if ok {
+			assert.Equal(t, wantFormat, strings.TrimSpace(format.of(text)), "%q", text)
+			assert.Equal(t, wantName, strings.TrimSpace(name.of(text)), "%q", text)
+		}
+	}
+	format, name, _ := scanHeader("=== [gen8vgc2021] Untitled 10 ===")
+	assert.Equal(t, "gen8vgc2021", format.of("=== [gen8vgc2021] Untitled 10 ==="))
+	assert.Equal(t, "Untitled 10", name.of("=== [gen8vgc2021] Untitled 10 ==="))
+}
+
+func FuzzScanLine(f *testing.F) {
+	for _, seed := range append(scanLineSeeds, headerSeeds...) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, text string) {
+		wantKind, wantValue := referenceScanLine(text)
+		kind, value := scanLine(text)
+		if kind != wantKind || wantKind != LineUnknown && value != wantValue {
+			t.Fatalf("scanLine(%q) = %v %v, want %v %v", text, kind, value, wantKind, wantValue)
+		}
+		if strings.IndexByte(text, '\n') >= 0 {
+			return // header lines never hold a line feed
+		}
+		wantFormat, wantName, wantOk := referenceScanHeader(text)
+		format, name, ok := scanHeader(text)
+		if ok != wantOk || ok && (strings.TrimSpace(format.of(text)) != wantFormat || strings.TrimSpace(name.of(text)) != wantName) {
+			t.Fatalf("scanHeader(%q) = %q %q %v, want %q %q %v", text, format.of(text), name.of(text), ok, wantFormat, wantName, wantOk)
+		}
+	})
+}
+
+func Test_splitBlocks(t *testing.T) {
+	s := `=== [gen8vgc2021] Untitled 10 ===
+
+Charizard-Gmax @ Wacan Berry  
+Ability: Solar Power  
+Level: 50  
+EVs: 4 HP / 252 SpA / 252 Spe  
+Timid Nature  
+IVs: 0 Atk  
+- Blast Burn  
+- Hurricane  
+- Ancient Power  
+- Protect  
+
+Venusaur-Gmax @ Coba Berry  
+Ability: Chlorophyll  
+Level: 50  
+EVs: 156 HP / 4 Def / 252 SpA / 4 SpD / 92 Spe  
+Modest Nature  
+IVs: 0 Atk  
+- Frenzy Plant  
+- Sludge Bomb  
+- Earth Power  
+- Sleep Powder  
+
+Tapu Fini @ Sitrus Berry  
+Ability: Misty Surge  
+EVs: 252 HP / 68 Def / 4 SpA / 116 SpD / 68 Spe  
+Calm Nature  
+IVs: 0 Atk  
+- Moonblast  
+- Icy Wind  
+- Haze  
+- Nature's Madness
+
+Thundurus @ Life Orb  
+Ability: Defiant  
+Level: 50  
+EVs: 4 HP / 252 Atk / 252 Spe  
+Jolly Nature  
+- Fly  
+- Wild Charge  
+- Superpower  
+- Protect  
+
+Urshifu @ Focus Sash  
+Ability: Unseen Fist  
+Level: 50  
+EVs: 252 Atk / 4 SpD / 252 Spe  
+Jolly Nature  
+- Close Combat  
+- Detect  
+- Wicked Blow  
+- Sucker Punch  
+
+Zacian @ Rusted Sword  
+Ability: Intrepid Sword  
+Level: 50  
+EVs: 252 HP / 108 Atk / 4 Def / 68 SpD / 76 Spe  
+Adamant Nature  
+- Iron Head  
+- Substitute  
+- Sacred Sword  
+- Protect  
+
+`
+	res := splitBlocks(s)
+	assert.Len(t, res, 7)
+	assert.True(t, isHeaderBlock(res[0]))
+	assert.Len(t, res[1], 10)
+	assert.Equal(t, sourceLine{text: "Ability: Solar Power", raw: "Ability: Solar Power  ", num: 4, col: 1}, res[1][1])
+
+	// a header without a following blank line, CRLF and indentation
+	res = splitBlocks("=== [gen8] Test ===\r\n  Koffing\r\n\tAbility: Levitate\r\n \r\nWeezing")
+	assert.Len(t, res, 3)
+	assert.Equal(t, sourceLine{text: "Ability: Levitate", raw: "\tAbility: Levitate", num: 3, col: 2}, res[1][1])
+	assert.Equal(t, 5, res[2][0].num)
+	assert.Len(t, splitLines("\n\nKoffing\n\nWeezing\n"), 2)
+}
+
+func Test_trimLines(t *testing.T) {
+	type args struct {
+		lines []string
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+	}{
+		{
+			name: "Zacian",
+			args: args{strings.Split("\nZacian @ Rusted Sword  \nAbility: Intrepid Sword  \nLevel: 50  \n", "\n")},
+			want: []string{"Zacian @ Rusted Sword", "Ability: Intrepid Sword", "Level: 50"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimLines(tt.args.lines); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("trimLines() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package koffing
+
+import "github.com/txfs19260817/koffing-go/dex"
+
+// Species returns the Pokédex data of the species of the receiver in the latest generation, or false if it is unknown.
+func (p Pokemon) Species() (dex.SpeciesData, bool) {
+	return dex.Species(p.Name)
+}
+
+// SpeciesGen is like Species, but returns the data of the given generation, e.g. the one of Team.Gen.
+// A gen of 0 means unknown, for which SpeciesGen is Species.
+func (p Pokemon) SpeciesGen(gen int) (dex.SpeciesData, bool) {
+	if gen == 0 {
+		return p.Species()
+	}
+	return dex.Gen(gen).Species(p.Name)
+}
@@ -0,0 +1,35 @@
+package koffing
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExamplePokemon_Species() {
+	var p Pokemon
+	_ = p.FromShowdown(koffingSet)
+	species, _ := p.Species()
+	fmt.Println(species.Types, species.BaseStats.Def, species.Abilities.Has(p.Ability), species.NFE())
+	// Output: [Poison] 95 true true
+}
+
+func TestPokemon_SpeciesGen(t *testing.T) {
+	t.Parallel()
+	p := Pokemon{Name: "Togekiss"}
+	species, ok := p.SpeciesGen(0)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Fairy", "Flying"}, species.Types)
+	species, ok = p.SpeciesGen(5)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"Normal", "Flying"}, species.Types)
+	_, ok = p.SpeciesGen(3)
+	assert.False(t, ok)
+	team := Team{Format: "gen4ou", Pokemon: []Pokemon{p}}
+	assert.Equal(t, 4, team.Gen())
+	_, ok = team.Pokemon[0].SpeciesGen(team.Gen())
+	assert.True(t, ok)
+	_, ok = Pokemon{Name: "Smogon"}.Species()
+	assert.False(t, ok)
+}
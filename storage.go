@@ -0,0 +1,80 @@
+package koffing
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FromStorage parses the team list that the Showdown client keeps in its localStorage and stores the result in the pointer receiver.
+// Each non-empty line is a team in the form of `format]folder/name|packedteam`, where the format and folder are optional.
+func (c *TeamCollection) FromStorage(s string) error {
+	lines := trimLines(strings.Split(s, "\n"))
+	c.Teams = make([]Team, 0, len(lines))
+	for i, line := range lines {
+		var t Team
+		if err := t.fromStorageLine(line); err != nil {
+			return fmt.Errorf("failed to import the storage line to a Team: index: %d, error: %w", i, err)
+		}
+		c.Teams = append(c.Teams, t)
+	}
+	return nil
+}
+
+// ToStorage returns the receiver as a team list of the Showdown client's localStorage.
+func (c TeamCollection) ToStorage() (string, error) {
+	var storage strings.Builder
+	for i, team := range c.Teams {
+		line, err := team.toStorageLine()
+		if err != nil {
+			return "", fmt.Errorf("failed to export a Team to storage: index: %d, error: %w", i, err)
+		}
+		if i > 0 {
+			storage.WriteByte('\n')
+		}
+		storage.WriteString(line)
+	}
+	return storage.String(), nil
+}
+
+// fromStorageLine parses a single `format]folder/name|packedteam` line as the Showdown client does.
+func (t *Team) fromStorageLine(line string) error {
+	pipe := strings.IndexByte(line, '|')
+	if pipe < 0 {
+		return fmt.Errorf("invalid storage line: missing packed team: %s", line)
+	}
+	meta := line[:pipe]
+	t.Format = ""
+	if bracket := strings.IndexByte(meta, ']'); bracket >= 0 {
+		t.Format, meta = meta[:bracket], meta[bracket+1:]
+	}
+	t.Folder, t.Name = "", meta
+	if slash := strings.LastIndexByte(meta, '/'); slash >= 0 {
+		t.Folder, t.Name = meta[:slash], meta[slash+1:]
+	}
+	return t.FromPacked(line[pipe+1:])
+}
+
+// toStorageLine returns the receiver as a `format]folder/name|packedteam` line.
+func (t Team) toStorageLine() (string, error) {
+	if strings.ContainsAny(t.Format, "]|\n") || strings.ContainsAny(t.Folder, "|\n") || strings.ContainsAny(t.Name, "/|\n") {
+		return "", fmt.Errorf("invalid team metadata for storage: format: %q, folder: %q, name: %q", t.Format, t.Folder, t.Name)
+	}
+	packed, err := t.ToPacked()
+	if err != nil {
+		return "", err
+	}
+	var line strings.Builder
+	line.Grow(len(t.Format) + len(t.Folder) + len(t.Name) + len(packed) + 3)
+	if len(t.Format) > 0 {
+		line.WriteString(t.Format)
+		line.WriteByte(']')
+	}
+	if len(t.Folder) > 0 {
+		line.WriteString(t.Folder)
+		line.WriteByte('/')
+	}
+	line.WriteString(t.Name)
+	line.WriteByte('|')
+	line.WriteString(packed)
+	return line.String(), nil
+}
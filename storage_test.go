@@ -0,0 +1,88 @@
+package koffing
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const storage = `gen8vgc2021]VGC/Charizard Rain|Charizard-Gmax||WacanBerry|SolarPower|BlastBurn,Hurricane,AncientPower,Protect|Timid|4,,,252,,252||,0,,,,||50|]Venusaur-Gmax||CobaBerry|Chlorophyll|FrenzyPlant,SludgeBomb,EarthPower,SleepPowder|Modest|156,,4,252,4,92||,0,,,,||50|
+gen7]Example Team|Smogon|Koffing|Eviolite|Levitate|WillOWisp,PainSplit|Bold|36,,236,,236,|F||S|5|
+Untitled 1|`
+
+func ExampleTeamCollection_FromStorage() {
+	c := new(TeamCollection)
+	_ = c.FromStorage(storage)
+	for _, t := range c.Teams {
+		fmt.Printf("format: %q, folder: %q, name: %q, pokemon: %d\n", t.Format, t.Folder, t.Name, len(t.Pokemon))
+	}
+	// Output: format: "gen8vgc2021", folder: "VGC", name: "Charizard Rain", pokemon: 2
+	// format: "gen7", folder: "", name: "Example Team", pokemon: 1
+	// format: "", folder: "", name: "Untitled 1", pokemon: 0
+}
+
+func TestTeamCollection_FromStorage(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromStorage(storage))
+	assert.Len(t, c.Teams, 3)
+	assert.Equal(t, "Wacan Berry", c.Teams[0].Pokemon[0].Item)
+	assert.Equal(t, "Smogon", c.Teams[1].Pokemon[0].Nickname)
+
+	assert.NoError(t, c.FromStorage("\r\n\r\n"))
+	assert.Len(t, c.Teams, 0)
+	assert.Error(t, c.FromStorage("gen8]No Team"))
+	assert.Error(t, c.FromStorage("gen8]Bad Team|Koffing"))
+}
+
+// TestTeamCollection_FromStorage_client checks the team list in testdata/storage, which is in the layout of the client:
+// names are IDs and abilities are the slots of the abilities of the species.
+func TestTeamCollection_FromStorage_client(t *testing.T) {
+	t.Parallel()
+	b, err := os.ReadFile(filepath.Join("testdata", "storage", "client.txt"))
+	assert.NoError(t, err)
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromStorage(string(b)))
+	assert.NoError(t, c.Teams[0].Validate())
+	charizard := c.Teams[0].Pokemon[0]
+	assert.Equal(t, "Wacan Berry", charizard.Item)
+	assert.Equal(t, "Solar Power", charizard.Ability)
+	assert.Equal(t, []string{"Blast Burn", "Hurricane", "Ancient Power", "Protect"}, charizard.Moves)
+	assert.Equal(t, "Chlorophyll", c.Teams[0].Pokemon[1].Ability)
+	koffing := c.Teams[1].Pokemon[0]
+	assert.Equal(t, "Koffing", koffing.Name)
+	assert.Equal(t, "Stench", koffing.Ability)
+	assert.Equal(t, []string{"Will-O-Wisp", "Pain Split"}, koffing.Moves)
+	// Pokémon have no abilities in gen 2
+	assert.Equal(t, "", c.Teams[2].Pokemon[0].Ability)
+	assert.NoError(t, c.Teams[2].Validate())
+
+	s, err := c.ToShowdown()
+	assert.NoError(t, err)
+	assert.Contains(t, s, "Charizard-Gmax @ Wacan Berry  \nAbility: Solar Power  \nLevel: 50  \n")
+	assert.Contains(t, s, "- Will-O-Wisp  \n")
+	assert.NotContains(t, s, "Ability: H")
+
+	// the slot of an ability missing from the species is an error
+	assert.Error(t, c.FromStorage("gen7]Example Team|Smogon|koffing|eviolite|1|willowisp|Bold||||||"))
+}
+
+func TestTeamCollection_ToStorage(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromStorage(storage))
+	s, err := c.ToStorage()
+	assert.NoError(t, err)
+	assert.Equal(t, storage, s)
+
+	c.Teams[2].Name = "A/B"
+	_, err = c.ToStorage()
+	assert.Error(t, err)
+	c.Teams[2].Name = "Untitled 1"
+	c.Teams[1].Pokemon[0].Moves = nil
+	_, err = c.ToStorage()
+	assert.Error(t, err)
+}
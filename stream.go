@@ -0,0 +1,301 @@
+package koffing
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Decoder reads Pokémon and teams from a stream of Showdown text, holding only one set or team in memory at a time.
+// Teams are delimited like TeamCollection.FromShowdown: each header line starts a new team,
+// and sets before the first header belong to a team without name.
+type Decoder struct {
+	r    *bufio.Reader
+	ps   *parser
+	err  error
+	done bool
+	// position in the input
+	num, size int
+	// a header line read ahead of the end of a team
+	pending *sourceLine
+	// whether a team has started, and whether the current one has not been returned by NextTeam yet
+	started  bool
+	open     bool
+	header   Team
+	pokemon  *Pokemon
+	team     *Team
+	warnings []*ParseError
+}
+
+// NewDecoder returns a Decoder reading from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return NewDecoderWithOptions(r, ParseOptions{})
+}
+
+// NewDecoderWithOptions returns a Decoder reading from r, which parses with opts.
+// The input limits of opts apply to the whole stream.
+func NewDecoderWithOptions(r io.Reader, opts ParseOptions) *Decoder {
+	return &Decoder{r: bufio.NewReader(r), ps: newParser(opts)}
+}
+
+// Next reads the next Pokémon set, which is then returned by Pokemon.
+// It returns false at the end of the input or on an error, which is returned by Err.
+func (d *Decoder) Next() bool {
+	d.pokemon, d.team, d.warnings = nil, nil, nil
+	for !d.done {
+		block, err := d.readBlock()
+		if err != nil {
+			d.fail(err)
+			break
+		}
+		if isHeaderBlock(block) {
+			d.startTeam(block[0])
+			continue
+		}
+		p, err := d.decodePokemon(block)
+		if err != nil {
+			d.fail(err)
+			break
+		}
+		d.pokemon = p
+		return true
+	}
+	return false
+}
+
+// NextTeam reads the rest of the current team, or the next team, which is then returned by Team.
+// It returns false at the end of the input or on an error, which is returned by Err.
+func (d *Decoder) NextTeam() bool {
+	d.pokemon, d.team, d.warnings = nil, nil, nil
+	team := d.header
+	team.Pokemon = make([]Pokemon, 0, 6)
+	for !d.done {
+		block, err := d.readBlock()
+		if err != nil {
+			d.fail(err)
+			break
+		}
+		if isHeaderBlock(block) {
+			if d.open {
+				// the header belongs to the next team
+				d.pending = &block[0]
+				break
+			}
+			d.startTeam(block[0])
+			team = d.header
+			team.Pokemon = make([]Pokemon, 0, 6)
+			continue
+		}
+		p, err := d.decodePokemon(block)
+		if err != nil {
+			d.fail(err)
+			break
+		}
+		team.Pokemon = append(team.Pokemon, *p)
+	}
+	if d.err != nil || !d.open {
+		return false
+	}
+	d.open = false
+	d.team = &team
+	return true
+}
+
+// Pokemon returns the Pokémon read by the last call to Next.
+func (d *Decoder) Pokemon() *Pokemon {
+	return d.pokemon
+}
+
+// Header returns the name, format and folder of the team of the Pokémon read by the last call to Next, without Pokémon.
+func (d *Decoder) Header() Team {
+	return d.header
+}
+
+// Team returns the team read by the last call to NextTeam.
+func (d *Decoder) Team() *Team {
+	return d.team
+}
+
+// TeamIndex returns the 0-based index of the current team in the input.
+func (d *Decoder) TeamIndex() int {
+	return d.ps.teamIndex
+}
+
+// Warnings returns the unrecognized lines of the Pokémon or team read by the last call to Next or NextTeam.
+func (d *Decoder) Warnings() []*ParseError {
+	return d.warnings
+}
+
+// Err returns the first error met by the Decoder other than io.EOF. A parsing error is a *ParseError.
+func (d *Decoder) Err() error {
+	return d.err
+}
+
+// fail stops decoding, recording err unless it is io.EOF.
+func (d *Decoder) fail(err error) {
+	d.done = true
+	if err != io.EOF {
+		d.err = err
+	}
+}
+
+// decodePokemon parses a set of the current team.
+func (d *Decoder) decodePokemon(block []sourceLine) (*Pokemon, error) {
+	d.started, d.open = true, true
+	d.ps.warnings = nil
+	if err := d.ps.countPokemon(block[0]); err != nil {
+		return nil, err
+	}
+	p := &Pokemon{}
+	if err := p.fromLines(d.ps, block); err != nil {
+		return nil, err
+	}
+	d.ps.pokemonIndex++
+	d.warnings = append(d.warnings, d.ps.warnings...)
+	return p, nil
+}
+
+// startTeam starts a new team at a header line.
+func (d *Decoder) startTeam(line sourceLine) {
+	if d.started {
+		d.ps.teamIndex++
+	}
+	d.started, d.open = true, true
+	d.ps.pokemonIndex = 0
+	d.header = Team{}
+	d.header.parseHeader(line.text)
+	d.ps.setFormat(d.header.Format)
+}
+
+// readBlock returns the next block of non-blank lines, like splitBlocks does.
+func (d *Decoder) readBlock() ([]sourceLine, error) {
+	if d.pending != nil {
+		line := *d.pending
+		d.pending = nil
+		return []sourceLine{line}, nil
+	}
+	var block []sourceLine
+	for {
+		line, err := d.readLine()
+		if err == io.EOF && len(block) > 0 {
+			return block, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case len(line.text) == 0:
+			if len(block) > 0 {
+				return block, nil
+			}
+		case isHeaderLine(line.text):
+			if len(block) == 0 {
+				return []sourceLine{line}, nil
+			}
+			d.pending = &line
+			return block, nil
+		default:
+			block = append(block, line)
+		}
+	}
+}
+
+// readLine returns the next line, checking it against the input limits.
+// The size is checked while reading, so that a line longer than the limit is never held in memory.
+func (d *Decoder) readLine() (sourceLine, error) {
+	opts := d.ps.opts
+	var raw strings.Builder
+	for {
+		chunk, err := d.r.ReadSlice('\n')
+		raw.Write(chunk)
+		d.size += len(chunk)
+		if opts.MaxInputSize > 0 && d.size > opts.MaxInputSize {
+			line := newSourceLine(strings.TrimSuffix(raw.String(), "\n"), d.num+1)
+			err := d.ps.errorAt(line, 0, CodeLimitExceeded, fmt.Errorf("input size exceeds the limit %d", opts.MaxInputSize))
+			err.PokemonIndex = -1
+			return sourceLine{}, err
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err != nil && (err != io.EOF || raw.Len() == 0) {
+			return sourceLine{}, err
+		}
+		break
+	}
+	d.num++
+	line := newSourceLine(strings.TrimSuffix(strings.TrimSuffix(raw.String(), "\n"), "\r"), d.num)
+	if opts.MaxLines > 0 && d.num > opts.MaxLines {
+		err := d.ps.errorAt(line, 0, CodeLimitExceeded, fmt.Errorf("number of lines exceeds the limit %d", opts.MaxLines))
+		err.PokemonIndex = -1
+		return sourceLine{}, err
+	}
+	return line, nil
+}
+
+// Encoding is the output format of an Encoder.
+type Encoding int
+
+const (
+	// EncodingShowdown writes Showdown text, following every set and team by a blank line like Showdown does.
+	EncodingShowdown Encoding = iota
+	// EncodingJSON writes newline-delimited JSON, one Pokémon or team per line.
+	EncodingJSON
+)
+
+// Encoder writes Pokémon and teams to a stream one at a time, reusing its buffer.
+type Encoder struct {
+	w        io.Writer
+	encoding Encoding
+	gen      int
+	buf      []byte
+}
+
+// NewEncoder returns an Encoder writing Showdown text to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// SetEncoding sets the output format of the Encoder.
+func (e *Encoder) SetEncoding(encoding Encoding) {
+	e.encoding = encoding
+}
+
+// SetGen sets the generation whose rules EncodePokemon applies, e.g. the Gen of the Header of a Decoder.
+// The default of 0 means unknown, see Pokemon.ValidateGen.
+func (e *Encoder) SetGen(gen int) {
+	e.gen = gen
+}
+
+// EncodePokemon validates p with the rules of the generation set by SetGen and writes it.
+func (e *Encoder) EncodePokemon(p Pokemon) error {
+	if err := p.ValidateGen(e.gen); err != nil {
+		return err
+	}
+	if e.encoding == EncodingJSON {
+		return e.write(p.AppendJSON(e.buf[:0]), nil)
+	}
+	return e.write(p.appendShowdown(e.buf[:0], ShowdownOptions{Gen: e.gen}))
+}
+
+// EncodeTeam validates t and writes it.
+func (e *Encoder) EncodeTeam(t Team) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	if e.encoding == EncodingJSON {
+		return e.write(t.AppendJSON(e.buf[:0]), nil)
+	}
+	return e.write(t.AppendShowdown(e.buf[:0]))
+}
+
+// write writes an encoded value followed by a line break, keeping the buffer for the next one.
+func (e *Encoder) write(b []byte, err error) error {
+	if err != nil {
+		return err
+	}
+	e.buf = append(b, '\n')
+	_, err = e.w.Write(e.buf)
+	return err
+}
@@ -0,0 +1,271 @@
+package koffing
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func ExampleDecoder() {
+	d := NewDecoder(strings.NewReader(backup))
+	for d.Next() {
+		fmt.Printf("%s: %s\n", d.Header().Name, d.Pokemon().Name)
+	}
+	if err := d.Err(); err != nil {
+		fmt.Println(err)
+	}
+	// Output: Charizard Rain: Charizard-Gmax
+	// Charizard Rain: Venusaur-Gmax
+	// Example Team: Koffing
+	// Untitled 1: Tapu Fini
+}
+
+func TestDecoder_NextTeam(t *testing.T) {
+	t.Parallel()
+	want := new(TeamCollection)
+	assert.NoError(t, want.FromShowdown(backup))
+
+	d := NewDecoder(strings.NewReader(backup))
+	var teams []Team
+	for d.NextTeam() {
+		assert.Equal(t, len(teams), d.TeamIndex())
+		teams = append(teams, *d.Team())
+	}
+	assert.NoError(t, d.Err())
+	assert.Equal(t, want.Teams, teams)
+	assert.False(t, d.Next())
+
+	// Next and NextTeam can be mixed
+	d = NewDecoder(strings.NewReader(backup))
+	assert.True(t, d.Next())
+	assert.True(t, d.NextTeam())
+	assert.Equal(t, "Charizard Rain", d.Team().Name)
+	assert.Equal(t, want.Teams[0].Pokemon[1:], d.Team().Pokemon)
+	assert.True(t, d.Next())
+	assert.Equal(t, "Example Team", d.Header().Name)
+	assert.Equal(t, 1, d.TeamIndex())
+}
+
+func TestDecoder_errors(t *testing.T) {
+	t.Parallel()
+	d := NewDecoderWithOptions(strings.NewReader("=== [gen8] A ===\n\n"+typoSet), ParseOptions{})
+	assert.True(t, d.Next())
+	assert.Len(t, d.Warnings(), 2)
+	assert.False(t, d.Next())
+	assert.NoError(t, d.Err())
+
+	tests := []struct {
+		name string
+		s    string
+		opts ParseOptions
+		code ErrorCode
+		line int
+	}{
+		{name: "strict", s: typoSet, opts: ParseOptions{Strict: true}, code: CodeUnknownLine, line: 2},
+		{name: "invalid name", s: backup + "\n\n(@)\nAbility: Levitate\n- Haze", code: CodeInvalidName, line: 50},
+		{name: "max input size", s: backup, opts: ParseOptions{MaxInputSize: 100}, code: CodeLimitExceeded, line: 5},
+		{name: "max lines", s: backup, opts: ParseOptions{MaxLines: 10}, code: CodeLimitExceeded, line: 11},
+		{name: "max pokemon", s: backup, opts: ParseOptions{MaxPokemon: 3}, code: CodeLimitExceeded, line: 39},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDecoderWithOptions(strings.NewReader(tt.s), tt.opts)
+			for d.Next() {
+			}
+			var parseErr *ParseError
+			assert.True(t, errors.As(d.Err(), &parseErr))
+			assert.Equal(t, tt.code, parseErr.Code)
+			assert.Equal(t, tt.line, parseErr.Line)
+		})
+	}
+}
+
+// repeatReader endlessly generates a set, up to n times, without holding the whole input.
+type repeatReader struct {
+	set  string
+	n    int
+	rest string
+}
+
+func (r *repeatReader) Read(p []byte) (int, error) {
+	if len(r.rest) == 0 {
+		if r.n == 0 {
+			return 0, io.EOF
+		}
+		r.n--
+		r.rest = r.set + "\n\n"
+	}
+	n := copy(p, r.rest)
+	r.rest = r.rest[n:]
+	return n, nil
+}
+
+// endlessLine is a reader of a line which never ends.
+type endlessLine struct{}
+
+func (endlessLine) Read(p []byte) (int, error) {
+	for i := range p {
+		p[i] = 'a'
+	}
+	return len(p), nil
+}
+
+func TestDecoder_longLine(t *testing.T) {
+	t.Parallel()
+	d := NewDecoderWithOptions(io.MultiReader(strings.NewReader("=== [gen8] A ===\n\n"), endlessLine{}), ParseOptions{MaxInputSize: 1 << 20})
+	assert.False(t, d.Next())
+	var parseErr *ParseError
+	assert.True(t, errors.As(d.Err(), &parseErr))
+	assert.Equal(t, CodeLimitExceeded, parseErr.Code)
+	assert.Equal(t, 3, parseErr.Line)
+}
+
+func TestDecoder_large(t *testing.T) {
+	t.Parallel()
+	d := NewDecoder(&repeatReader{set: typoSet, n: 10000})
+	count := 0
+	for d.Next() {
+		count++
+	}
+	assert.NoError(t, d.Err())
+	assert.Equal(t, 10000, count)
+}
+
+func ExampleEncoder() {
+	var b strings.Builder
+	d := NewDecoder(strings.NewReader(backup))
+	e := NewEncoder(&b)
+	for d.NextTeam() {
+		if d.Team().Format == "gen7" {
+			_ = e.EncodeTeam(*d.Team())
+		}
+	}
+	// Showdown ends every line with two spaces, which are trimmed here to fit in this comment
+	fmt.Print(strings.ReplaceAll(b.String(), "  \n", "\n"))
+	// Output: === [gen7] Folder 1/Sub Folder/Example Team ===
+	//
+	// Smogon (Koffing) (F) @ Eviolite
+	// Ability: Levitate
+	// Level: 5
+	// EVs: 36 HP / 236 Def / 236 SpD
+	// Bold Nature
+	// - Will-O-Wisp
+	// - Pain Split
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+	c := new(TeamCollection)
+	assert.NoError(t, c.FromShowdown(backup))
+	want, err := c.ToShowdown()
+	assert.NoError(t, err)
+
+	var b bytes.Buffer
+	e := NewEncoder(&b)
+	for _, team := range c.Teams {
+		assert.NoError(t, e.EncodeTeam(team))
+	}
+	assert.Equal(t, want, b.String())
+
+	b.Reset()
+	e = NewEncoder(&b)
+	e.SetEncoding(EncodingJSON)
+	d := NewDecoder(strings.NewReader(backup))
+	for d.Next() {
+		assert.NoError(t, e.EncodePokemon(*d.Pokemon()))
+	}
+	lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	assert.Len(t, lines, 4)
+	p := &Pokemon{}
+	assert.NoError(t, p.FromJson(lines[3]))
+	assert.Equal(t, c.Teams[2].Pokemon[0], *p)
+
+	assert.Error(t, e.EncodePokemon(Pokemon{}))
+	assert.Error(t, NewEncoder(&b).EncodeTeam(Team{}))
+	// Showdown text is validated as well
+	b.Reset()
+	assert.Error(t, NewEncoder(&b).EncodePokemon(Pokemon{Name: "Koffing", Moves: []string{"Haze"}}))
+	assert.Empty(t, b.String())
+
+	// Pokémon are validated with the rules of the generation set by SetGen
+	e = NewEncoder(&b)
+	d = NewDecoder(strings.NewReader(gscTeam))
+	for d.Next() {
+		e.SetGen(d.Header().Gen())
+		p = d.Pokemon()
+		assert.NoError(t, e.EncodePokemon(*p))
+	}
+	assert.NoError(t, d.Err())
+	assert.Equal(t, "Snorlax @ Leftovers  \n- Body Slam  \n- Curse  \n- Rest  \n- Sleep Talk  \n\n"+
+		"Zapdos @ Leftovers  \nIVs: 26 Def  \n- Thunder  \n- Hidden Power [Ice]  \n- Rest  \n- Sleep Talk  \n\n", b.String())
+	e.SetGen(0)
+	assert.Error(t, e.EncodePokemon(*p))
+}
+
+func FuzzDecoder(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s)
+	}
+	f.Fuzz(func(t *testing.T, s string) {
+		want := new(TeamCollection)
+		wantErr := want.FromShowdown(s)
+		d := NewDecoder(strings.NewReader(s))
+		var teams []Team
+		for d.NextTeam() {
+			teams = append(teams, *d.Team())
+		}
+		if wantErr != nil {
+			if fmt.Sprint(wantErr) != fmt.Sprint(d.Err()) {
+				t.Fatalf("got error %v, want %v", d.Err(), wantErr)
+			}
+			return
+		}
+		if d.Err() != nil {
+			t.Fatalf("unexpected error %v", d.Err())
+		}
+		assert.Equal(t, len(want.Teams), len(teams))
+		for i := range teams {
+			assert.Equal(t, want.Teams[i], teams[i])
+		}
+	})
+}
+
+func FuzzDecoder_Next(f *testing.F) {
+	for _, s := range showdownSeeds {
+		f.Add(s, 0, 0)
+	}
+	f.Add(backup, 10, 2)
+	f.Fuzz(func(t *testing.T, s string, maxLines, maxPokemon int) {
+		opts := ParseOptions{MaxLines: maxLines, MaxPokemon: maxPokemon}
+		want := new(TeamCollection)
+		_, wantErr := want.FromShowdownWithOptions(s, opts)
+		d := NewDecoderWithOptions(strings.NewReader(s), opts)
+		count := 0
+		for d.Next() {
+			count++
+			_ = d.Header()
+			exportPokemon(*d.Pokemon())
+		}
+		if wantErr != nil {
+			if d.Err() == nil {
+				t.Fatalf("got no error, want %v", wantErr)
+			}
+			return
+		}
+		if d.Err() != nil {
+			t.Fatalf("unexpected error %v", d.Err())
+		}
+		wantCount := 0
+		for _, team := range want.Teams {
+			wantCount += len(team.Pokemon)
+		}
+		if count != wantCount {
+			t.Fatalf("got %d Pokémon, want %d", count, wantCount)
+		}
+	})
+}
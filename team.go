@@ -2,6 +2,7 @@ package koffing
 
 import (
 	"fmt"
+	"io"
 	"strings"
 )
 
@@ -24,60 +25,164 @@ func (t Team) ToJson() (string, error) {
 }
 
 // FromShowdown parses the Showdown paste/text and stores the result in the pointer receiver.
+// Unrecognized lines are ignored. The returned error is a *ParseError if the text cannot be parsed.
 func (t *Team) FromShowdown(s string) error {
-	parts := splitByEmptyNewline(s)
-	if teamTagRegex.MatchString(parts[0]) {
-		teamTags := teamTagRegex.FindStringSubmatch(parts[0])
-		t.Format = teamTags[1]
-		if name := strings.Split(teamTags[2], "/"); len(name) == 2 {
-			t.Folder, t.Name = name[0], name[1]
-		} else {
-			t.Name = name[0]
-		}
-		parts = parts[1:]
+	_, err := t.FromShowdownWithOptions(s, ParseOptions{})
+	return err
+}
+
+// FromShowdownWithOptions is like FromShowdown, but parses with opts.
+// It returns the unrecognized lines as warnings unless opts.Strict is set, in which case they are errors.
+func (t *Team) FromShowdownWithOptions(s string, opts ParseOptions) (warnings []*ParseError, err error) {
+	ps := newParser(opts)
+	if err := ps.checkInput(s); err != nil {
+		return ps.result(err)
+	}
+	return ps.result(t.fromBlocks(ps, splitBlocks(s)))
+}
+
+// fromBlocks parses the blocks of a team, the first of which may be the team header.
+func (t *Team) fromBlocks(ps *parser, blocks [][]sourceLine) *ParseError {
+	if len(blocks) > 0 && isHeaderBlock(blocks[0]) {
+		t.parseHeader(blocks[0][0].text)
+		blocks = blocks[1:]
 	}
+	ps.setFormat(t.Format)
 	t.Pokemon = make([]Pokemon, 0, 6)
-	for i, part := range parts {
+	for i, block := range blocks {
+		ps.pokemonIndex = i
+		if isHeaderBlock(block) {
+			err := ps.errorAt(block[0], 0, CodeUnexpectedHeader, fmt.Errorf("unexpected team header: %s", block[0].text))
+			err.PokemonIndex = -1
+			return err
+		}
+		if err := ps.countPokemon(block[0]); err != nil {
+			return err
+		}
 		var p Pokemon
-		if err := p.FromShowdown(part); err != nil {
-			return fmt.Errorf("failed to import the Showdown text to a Pokemon: index: %d, error: %w", i, err)
+		if err := p.fromLines(ps, block); err != nil {
+			return err
 		}
 		t.Pokemon = append(t.Pokemon, p)
 	}
 	return nil
 }
 
-// ToShowdown returns the Showdown paste/text of the receiver.
+// isHeaderBlock reports whether the block is a team header line.
+func isHeaderBlock(block []sourceLine) bool {
+	return len(block) == 1 && isHeaderLine(block[0].text)
+}
+
+// parseHeader extracts Format, Folder and Name from a team header line
+// like `=== [gen7] Folder 1/Example Team ===`. The format tag is optional,
+// and the folder is everything before the last slash, as in Showdown.
+func (t *Team) parseHeader(line string) {
+	format, nameSpan, _ := scanHeader(line)
+	t.Format = strings.TrimSpace(format.of(line))
+	name := strings.TrimSpace(nameSpan.of(line))
+	if slash := strings.LastIndex(name, "/"); slash > 0 {
+		t.Folder, t.Name = name[:slash], name[slash+1:]
+	} else {
+		t.Folder, t.Name = "", name
+	}
+}
+
+// appendHeader appends the team header line of the receiver without the trailing newline.
+func (t Team) appendHeader(dst []byte) []byte {
+	dst = append(dst, "=== "...)
+	if len(t.Format) > 0 {
+		dst = append(dst, '[')
+		dst = append(dst, t.Format...)
+		dst = append(dst, "] "...)
+	}
+	if len(t.Folder) > 0 {
+		dst = append(dst, t.Folder...)
+		dst = append(dst, '/')
+	}
+	dst = append(dst, t.Name...)
+	return append(dst, " ==="...)
+}
+
+// ToShowdown returns the Showdown paste/text of the receiver. Like Teams.export on Pokémon Showdown, every set is followed by a blank line.
+// The EVs/IVs lines are written for the generation of the format, see ShowdownOptions.Gen.
 func (t Team) ToShowdown() (string, error) {
-	var showdown strings.Builder
-	if len(t.Name) > 0 {
-		if len(t.Folder) > 0 {
-			showdown.WriteString(fmt.Sprintf("=== [%s] %s/%s ===\n\n", t.Format, t.Folder, t.Name))
-		} else {
-			showdown.WriteString(fmt.Sprintf("=== [%s] %s ===\n\n", t.Format, t.Name))
-		}
+	return t.ToShowdownWithOptions(ShowdownOptions{})
+}
+
+// ToShowdownWithOptions is like ToShowdown, but writes the Pokémon with opts.
+func (t Team) ToShowdownWithOptions(opts ShowdownOptions) (string, error) {
+	showdown, err := t.appendShowdown(make([]byte, 0, 300*len(t.Pokemon)+100), opts)
+	if err != nil {
+		return "", err
 	}
+	return string(showdown), nil
+}
+
+// AppendShowdown appends the Showdown paste/text of the receiver to dst like ToShowdown writes it, and returns the extended buffer.
+// On failure, dst is returned unchanged.
+func (t Team) AppendShowdown(dst []byte) ([]byte, error) {
+	return t.appendShowdown(dst, ShowdownOptions{})
+}
 
+// WriteTo implements io.WriterTo by writing the Showdown paste/text of the receiver like ToShowdown writes it.
+func (t Team) WriteTo(w io.Writer) (int64, error) {
+	return writeBuffered(w, func(dst []byte) ([]byte, error) {
+		return t.appendShowdown(dst, ShowdownOptions{})
+	})
+}
+
+func (t Team) appendShowdown(dst []byte, opts ShowdownOptions) ([]byte, error) {
+	if opts.Gen == 0 {
+		opts.Gen = t.Gen()
+	}
+	showdown := dst
+	if len(t.Name) > 0 {
+		showdown = t.appendHeader(showdown)
+		showdown = append(showdown, "\n\n"...)
+	}
 	for i, pokemon := range t.Pokemon {
-		p, err := pokemon.ToShowdown()
-		if err != nil {
-			return "", fmt.Errorf("failed to export a Pokemon to Showdown: index: %d, error: %w", i, err)
+		var err error
+		if showdown, err = pokemon.appendShowdown(showdown, opts); err != nil {
+			return dst, fmt.Errorf("failed to export a Pokemon to Showdown: index: %d, error: %w", i, err)
 		}
-		showdown.Grow(len(p))
-		showdown.WriteString(p)
+		showdown = append(showdown, '\n')
 	}
-	return showdown.String(), nil
+	return showdown, nil
 }
 
-// Validate essentially validates each Pokemon in this Team.
+// Validate essentially validates each Pokemon in this Team, including the generation-specific rules of its Format.
 func (t Team) Validate() error {
 	if len(t.Pokemon) == 0 {
 		return fmt.Errorf("empty team members")
 	}
+	gen := formatGen(t.Format)
 	for i, pokemon := range t.Pokemon {
-		if err := pokemon.Validate(); err != nil {
+		if err := pokemon.ValidateGen(gen); err != nil {
 			return fmt.Errorf("found an invalid Pokemon: index: %d, error: %w", i, err)
 		}
 	}
 	return nil
 }
+
+// Gen returns the generation of the format of the receiver, e.g. 8 for "gen8vgc2021", or 0 if it is unknown.
+func (t Team) Gen() int {
+	return formatGen(t.Format)
+}
+
+// formatGen returns the generation of a Showdown format ID like "gen8vgc2021", or 0 if it is unknown.
+func formatGen(format string) int {
+	if !strings.HasPrefix(format, "gen") {
+		return 0
+	}
+	gen := 0
+	for _, c := range format[len("gen"):] {
+		if c < '0' || c > '9' {
+			break
+		}
+		gen = gen*10 + int(c-'0')
+		if gen > 99 {
+			return 0
+		}
+	}
+	return gen
+}
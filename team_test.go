@@ -2,6 +2,8 @@ package koffing
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -13,7 +15,7 @@ func ExampleTeam_FromJson() {
 	j := `{"name":"Example Team","format":"gen7","folder":"Folder 1","pokemon":[{"name":"Koffing","nickname":"Smogon","gender":"F","item":"Eviolite","ability":"Levitate","level":5,"shiny":true,"happiness":255,"nature":"Bold","evs":{"hp":36,"def":236,"spd":236},"ivs":{"hp":31,"atk":30,"spa":31,"spd":30,"spe":31},"moves":["Will-O-Wisp","Pain Split","Sludge Bomb","Fire Blast"]},{"name":"Weezing","item":"Black Sludge","ability":"Levitate","nature":"Bold","evs":{"hp":252,"def":160,"spe":96},"moves":["Sludge Bomb","Will-O-Wisp","Toxic Spikes","Taunt"]}]}`
 	_ = team.FromJson(j)
 	fmt.Printf("%+v", team)
-	// Output: &{Name:Example Team Format:gen7 Folder:Folder 1 Pokemon:[{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Levitate Level:5 Shiny:true Happiness:255 Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:0 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]} {Name:Weezing Nickname: Gender: Item:Black Sludge Ability:Levitate Level:0 Shiny:false Happiness:0 Nature:Bold Evs:{Hp:252 Atk:0 Def:160 Spa:0 Spd:0 Spe:96} Ivs:{Hp:0 Atk:0 Def:0 Spa:0 Spd:0 Spe:0} Moves:[Sludge Bomb Will-O-Wisp Toxic Spikes Taunt]}]}
+	// Output: &{Name:Example Team Format:gen7 Folder:Folder 1 Pokemon:[{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Levitate Level:5 Shiny:true Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:31 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]} {Name:Weezing Nickname: Gender: Item:Black Sludge Ability:Levitate Level:0 Shiny:false Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:252 Atk:0 Def:160 Spa:0 Spd:0 Spe:96} Ivs:{Hp:31 Atk:31 Def:31 Spa:31 Spd:31 Spe:31} Moves:[Sludge Bomb Will-O-Wisp Toxic Spikes Taunt]}]}
 }
 
 func TestTeam_FromJson(t *testing.T) {
@@ -59,7 +61,7 @@ func ExampleTeam_FromShowdown() {
 	team := new(Team)
 	_ = team.FromShowdown(s)
 	fmt.Printf("%+v", team)
-	// Output: &{Name:Example Team Format:gen7 Folder:Folder 1 Pokemon:[{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Levitate Level:5 Shiny:true Happiness:255 Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:31 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]} {Name:Venusaur-Gmax Nickname: Gender: Item:Coba Berry Ability:Chlorophyll Level:50 Shiny:false Happiness:255 Nature:Modest Evs:{Hp:156 Atk:0 Def:4 Spa:252 Spd:4 Spe:92} Ivs:{Hp:31 Atk:0 Def:31 Spa:31 Spd:31 Spe:31} Moves:[Frenzy Plant Sludge Bomb Earth Power Sleep Powder]}]}
+	// Output: &{Name:Example Team Format:gen7 Folder:Folder 1 Pokemon:[{Name:Koffing Nickname:Smogon Gender:F Item:Eviolite Ability:Levitate Level:5 Shiny:true Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Bold Evs:{Hp:36 Atk:0 Def:236 Spa:0 Spd:236 Spe:0} Ivs:{Hp:31 Atk:30 Def:31 Spa:31 Spd:30 Spe:31} Moves:[Will-O-Wisp Pain Split Sludge Bomb Fire Blast]} {Name:Venusaur-Gmax Nickname: Gender: Item:Coba Berry Ability:Chlorophyll Level:50 Shiny:false Happiness:255 Pokeball: HiddenPowerType: DynamaxLevel:10 Gigantamax:false TeraType: Nature:Modest Evs:{Hp:156 Atk:0 Def:4 Spa:252 Spd:4 Spe:92} Ivs:{Hp:31 Atk:0 Def:31 Spa:31 Spd:31 Spe:31} Moves:[Frenzy Plant Sludge Bomb Earth Power Sleep Powder]}]}
 }
 
 func TestTeam_FromShowdown(t *testing.T) {
@@ -160,6 +162,29 @@ func TestTeam_FromShowdown(t *testing.T) {
 	}
 }
 
+func TestTeam_parseHeader(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		line   string
+		format string
+		folder string
+		name   string
+	}{
+		{line: "=== [gen7] Folder 1/Example Team ===", format: "gen7", folder: "Folder 1", name: "Example Team"},
+		{line: "=== [gen8vgc2021] Untitled 10 ===", format: "gen8vgc2021", name: "Untitled 10"},
+		{line: "=== Untitled 1 ===", name: "Untitled 1"},
+		{line: "=== [gen9ou] A/B/C ===", format: "gen9ou", folder: "A/B", name: "C"},
+	}
+	for _, tt := range tests {
+		team := new(Team)
+		team.parseHeader(tt.line)
+		assert.Equal(t, tt.format, team.Format, tt.line)
+		assert.Equal(t, tt.folder, team.Folder, tt.line)
+		assert.Equal(t, tt.name, team.Name, tt.line)
+		assert.Equal(t, tt.line, string(team.appendHeader(nil)))
+	}
+}
+
 func ExampleTeam_ToJson() {
 	team := Team{
 		Name:    "Test",
@@ -190,7 +215,7 @@ func ExampleTeam_ToShowdown() {
 		Name:   "Test",
 		Format: "gen8",
 		Folder: "Folder 0",
-		Pokemon: []Pokemon{{Name: "Koffing", Ability: "Neutralizing Gas", Nature: "Bold", Moves: []string{"Haze"}, Ivs: struct {
+		Pokemon: []Pokemon{{Name: "Koffing", Ability: "Neutralizing Gas", Nature: "Bold", Happiness: 255, Moves: []string{"Haze"}, Ivs: struct {
 			Hp  int `json:"hp"`
 			Atk int `json:"atk"`
 			Def int `json:"def"`
@@ -200,7 +225,7 @@ func ExampleTeam_ToShowdown() {
 		}{Hp: 31, Def: 31, Spd: 31}}},
 	}
 	s, _ := team.ToShowdown()
-	fmt.Print(s)
+	fmt.Print(strings.ReplaceAll(s, "  \n", "\n"))
 	// Output: === [gen8] Folder 0/Test ===
 	//
 	//Koffing
@@ -216,7 +241,7 @@ func TestTeam_ToShowdown(t *testing.T) {
 		Name:   "Test",
 		Format: "gen8",
 		Folder: "Folder 0",
-		Pokemon: []Pokemon{{Name: "Koffing", Ability: "Neutralizing Gas", Nature: "Bold", Moves: []string{"Haze"}, Ivs: struct {
+		Pokemon: []Pokemon{{Name: "Koffing", Ability: "Neutralizing Gas", Nature: "Bold", Happiness: 255, Moves: []string{"Haze"}, Ivs: struct {
 			Hp  int `json:"hp"`
 			Atk int `json:"atk"`
 			Def int `json:"def"`
@@ -225,21 +250,15 @@ func TestTeam_ToShowdown(t *testing.T) {
 			Spe int `json:"spe"`
 		}{Hp: 31, Def: 31, Spd: 31}}},
 	}
-	expected := `=== [gen8] Folder 0/Test ===
-        
-        Koffing
-        Ability: Neutralizing Gas
-        Bold Nature
-        IVs: 0 Atk / 0 SpA / 0 Spe
-        - Haze
-		`
 	s, err := team.ToShowdown()
 	assert.NoError(t, err)
-	expectedSlice, actualSlice := strings.Split(expected, "\n"), strings.Split(s, "\n")
-	assert.Equal(t, len(expectedSlice), len(actualSlice))
-	for i, e := range expectedSlice {
-		assert.Equal(t, strings.TrimSpace(e), strings.TrimSpace(actualSlice[i]))
-	}
+	assert.Equal(t, "=== [gen8] Folder 0/Test ===\n\nKoffing  \nAbility: Neutralizing Gas  \nBold Nature  \nIVs: 0 Atk / 0 SpA / 0 Spe  \n- Haze  \n\n", s)
+
+	team.Name = ""
+	team.Pokemon = append(team.Pokemon, team.Pokemon[0])
+	s, err = team.ToShowdown()
+	assert.NoError(t, err)
+	assert.Equal(t, "Koffing  \nAbility: Neutralizing Gas  \nBold Nature  \nIVs: 0 Atk / 0 SpA / 0 Spe  \n- Haze  \n\nKoffing  \nAbility: Neutralizing Gas  \nBold Nature  \nIVs: 0 Atk / 0 SpA / 0 Spe  \n- Haze  \n\n", s)
 }
 
 func ExampleTeam_Validate() {
@@ -266,3 +285,63 @@ func TestTeam_Validate(t *testing.T) {
 	team.Pokemon = nil
 	assert.Error(t, team.Validate())
 }
+
+// TestTeam_ToShowdown_golden checks that the exports of Pokémon Showdown in testdata/export are parsed and exported again byte for byte.
+// A file starting with a team header is a "Backup all teams" dump, otherwise it is a team exported by Teams.export
+// in the generation its name starts with, e.g. gen 2 for gen2.txt.
+// The origin of every file is recorded in testdata/README.md.
+func TestTeam_ToShowdown_golden(t *testing.T) {
+	t.Parallel()
+	files, err := filepath.Glob(filepath.Join("testdata", "export", "*.txt"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+	readme, err := os.ReadFile(filepath.Join("testdata", "README.md"))
+	assert.NoError(t, err)
+	for _, file := range files {
+		assert.Contains(t, string(readme), "| export/"+filepath.Base(file)+" |", "origin of %s", file)
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			b, err := os.ReadFile(file)
+			assert.NoError(t, err)
+			want := string(b)
+			var got string
+			if strings.HasPrefix(want, "===") {
+				c := TeamCollection{}
+				assert.NoError(t, c.FromShowdown(want))
+				for _, team := range c.Teams {
+					for _, p := range team.Pokemon {
+						assert.NoError(t, p.ValidateGen(team.Gen()), "%s of %s", p.Name, team.Name)
+					}
+				}
+				got, err = c.ToShowdown()
+			} else {
+				gen := formatGen(filepath.Base(file))
+				team := Team{}
+				_, err := team.FromShowdownWithOptions(want, ParseOptions{Gen: gen})
+				assert.NoError(t, err)
+				// the exporter only writes sets that are legal in the generation, e.g. gen 2 derives shininess from the DVs
+				for _, p := range team.Pokemon {
+					assert.NoError(t, p.ValidateGen(gen), p.Name)
+				}
+				got, err = team.ToShowdownWithOptions(ShowdownOptions{Gen: gen})
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func BenchmarkTeam_FromShowdown(b *testing.B) {
+	bs, err := os.ReadFile("testdata/export/gen9vgc.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	s := "=== [gen9vgc2024regg] Bench ===\n\n" + string(bs)
+	b.SetBytes(int64(len(s)))
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		var t Team
+		if err := t.FromShowdown(s); err != nil {
+			b.Fatal(err)
+		}
+	}
+}